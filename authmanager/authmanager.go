@@ -2,6 +2,7 @@ package authmanager
 
 import (
 	"bufio"
+	"context"
 	"fmt"
 	"os"
 	"strings"
@@ -17,35 +18,63 @@ const (
 	authTokenEnvVariable = "TODOCHECK_AUTH_TOKEN"
 )
 
+// PromptForTokens, which are neither in their environment variable, nor in the tokens cache.
+// Disabled when stdin & stdout are used for something else, e.g. by the lsp command
+var PromptForTokens = true
+
 // AcquireToken stores the issue tracker's auth token based on the auth type specified
-func AcquireToken(cfg *config.Local, tracker issuetracker.IssueTracker) error {
-	if !cfg.Auth.Type.IsValid() {
-		return fmt.Errorf("invalid auth type: %q. valid auth types are: %q", cfg.Auth.Type, config.ValidAuthTypes)
-	} else if cfg.Auth.Type == config.AuthTypeNone {
+func AcquireToken(ctx context.Context, cfg *config.Local, tracker issuetracker.IssueTracker) error {
+	return acquireTrackerToken(ctx, cfg.Auth, cfg.Origin, authTokenEnvVariable, "", tracker)
+}
+
+// AcquireNamedToken stores the auth token of the given named issue tracker based on the auth type specified.
+// Instead of TODOCHECK_AUTH_TOKEN, its token can be provided via the TODOCHECK_AUTH_TOKEN_{NAME} environment variable,
+// e.g. TODOCHECK_AUTH_TOKEN_GH for an issue tracker named gh. The tokens of issue trackers, set by overrides, are provided via TODOCHECK_AUTH_TOKEN
+func AcquireNamedToken(ctx context.Context, cfg *config.NamedIssueTracker, tracker issuetracker.IssueTracker) error {
+	envVariable := namedTokenEnvVariable(cfg.Name)
+	if cfg.IsOverride() {
+		envVariable = authTokenEnvVariable
+	}
+
+	return acquireTrackerToken(ctx, cfg.Auth, cfg.Origin, envVariable, cfg.DisplayName(), tracker)
+}
+
+// namedTokenEnvVariable returns the environment variable, which holds the auth token of the issue tracker with the given name
+func namedTokenEnvVariable(name string) string {
+	return authTokenEnvVariable + "_" + strings.ToUpper(strings.ReplaceAll(name, "-", "_"))
+}
+
+// acquireTrackerToken of the issue tracker with the given name. The default issue tracker's name is empty
+func acquireTrackerToken(ctx context.Context, authCfg *config.Auth, origin, envVariable, name string, tracker issuetracker.IssueTracker) error {
+	if !authCfg.Type.IsValid() {
+		return fmt.Errorf("invalid auth type: %q. valid auth types are: %q", authCfg.Type, config.ValidAuthTypes)
+	} else if authCfg.Type == config.AuthTypeNone {
 		return nil
 	}
 
-	tokenKey := cfg.Origin
-	if cfg.Auth.Type == config.AuthTypeOffline {
-		tokenKey = cfg.Auth.OfflineURL
+	tokenKey := origin
+	if authCfg.Type == config.AuthTypeOffline {
+		tokenKey = authCfg.OfflineURL
 	}
 
 	instructions := tracker.TokenAcquisitionInstructions()
 	if instructions == "" {
 		panic("It's on us! We don't know how to handle this authentication token type." +
 			" Please file an issue here - https://github.com/preslavmihaylov/todocheck/issues/new")
+	} else if name != "" {
+		instructions = fmt.Sprintf("Issue tracker %s: %s", name, instructions)
 	}
 
-	return acquireToken(cfg.Auth, tokenKey, instructions)
+	return acquireToken(ctx, authCfg, tokenKey, envVariable, instructions)
 }
 
-func acquireToken(authCfg *config.Auth, tokenKey string, instructions string) error {
+func acquireToken(ctx context.Context, authCfg *config.Auth, tokenKey, envVariable, instructions string) error {
 	store, err := authstore.CreateIfNotExists(authCfg.TokensCache, authstore.DefaultConfigPermissions)
 	if err != nil {
 		return fmt.Errorf("couldn't read auth tokens config: %w", err)
 	}
 
-	if envToken := os.Getenv(authTokenEnvVariable); envToken != "" {
+	if envToken := os.Getenv(envVariable); envToken != "" {
 		authCfg.Token = envToken
 		return nil
 	} else if store.Tokens[tokenKey] != "" {
@@ -53,8 +82,12 @@ func acquireToken(authCfg *config.Auth, tokenKey string, instructions string) er
 		return nil
 	}
 
+	if !PromptForTokens {
+		return fmt.Errorf("no token found in the %s environment variable or the tokens cache %s", envVariable, authCfg.TokensCache)
+	}
+
 	fmt.Printf("%s\nToken: ", instructions)
-	tokenBs, err := readPassword()
+	tokenBs, err := readPassword(ctx)
 	if err != nil {
 		return fmt.Errorf("couldn't acquire token: %w", err)
 	}
@@ -65,14 +98,44 @@ func acquireToken(authCfg *config.Auth, tokenKey string, instructions string) er
 
 // Make token input scriptable, while preserving the hidden prompt behavior for users
 // https://github.com/golang/go/issues/19909#issuecomment-399409958
-func readPassword() ([]byte, error) {
+// If the context is done before the token is entered, e.g. on interrupt, the terminal's state is restored & the context's error is returned
+func readPassword(ctx context.Context) ([]byte, error) {
+	type result struct {
+		token []byte
+		err   error
+	}
+
 	fd := int(syscall.Stdin)
+	var state *terminal.State
 	if terminal.IsTerminal(fd) {
-		return terminal.ReadPassword(fd)
+		var err error
+		if state, err = terminal.GetState(fd); err != nil {
+			return nil, err
+		}
 	}
 
-	reader := bufio.NewReader(os.Stdin)
-	return reader.ReadBytes('\n')
+	results := make(chan result, 1)
+	go func() {
+		if state != nil {
+			token, err := terminal.ReadPassword(fd)
+			results <- result{token, err}
+			return
+		}
+
+		token, err := bufio.NewReader(os.Stdin).ReadBytes('\n')
+		results <- result{token, err}
+	}()
+
+	select {
+	case res := <-results:
+		return res.token, res.err
+	case <-ctx.Done():
+		if state != nil {
+			terminal.Restore(fd, state)
+		}
+
+		return nil, ctx.Err()
+	}
 }
 
 func setAndPersistToken(authCfg *config.Auth, store *authstore.Config, key, token string) error {
@@ -0,0 +1,179 @@
+// Package baseline contains the todo errors, which were present when todocheck was adopted on a codebase.
+// Errors in the baseline are not reported, so that only new errors fail the check
+package baseline
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/preslavmihaylov/todocheck/checker/errors"
+)
+
+// DefaultFile the baseline is written to, relative to the basepath
+const DefaultFile = ".todocheck-baseline.json"
+
+// DefaultFilePermissions the baseline file is created with
+var DefaultFilePermissions = os.FileMode(0644)
+
+// Entry of the baseline. Todo errors are matched by file, type, issue ID & the hash of their normalized comment text,
+// rather than line number, so that entries are still matched after the todo is moved within its file or the code next to it changes
+type Entry struct {
+	File    string `json:"file"`
+	Type    string `json:"type"`
+	IssueID string `json:"issueID,omitempty"`
+	Hash    string `json:"hash"`
+
+	// Count of the identical todo errors, e.g. for a todo which was copied within the same file
+	Count int `json:"count"`
+}
+
+// file is the on-disk format of the baseline
+type file struct {
+	Errors []*Entry `json:"errors"`
+}
+
+// key of an entry, which todo errors are matched by
+type key struct {
+	file, errType, issueID, hash string
+}
+
+// Baseline of todo errors
+type Baseline struct {
+	basepath string
+	counts   map[key]int
+	matched  map[key]int
+}
+
+// New empty baseline for the todo errors in the given basepath
+func New(basepath string) *Baseline {
+	return &Baseline{basepath: basepath, counts: map[key]int{}, matched: map[key]int{}}
+}
+
+// Load the baseline from the given file for the todo errors in the given basepath
+func Load(filename, basepath string) (*Baseline, error) {
+	bs, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't read baseline %s: %w", filename, err)
+	}
+
+	var f file
+	if err := json.Unmarshal(bs, &f); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal baseline %s: %w", filename, err)
+	}
+
+	b := New(basepath)
+	for _, e := range f.Errors {
+		b.counts[key{e.File, e.Type, e.IssueID, e.Hash}] += e.Count
+	}
+
+	return b, nil
+}
+
+// Add the given todo error to the baseline
+func (b *Baseline) Add(todoErr *errors.TODO) {
+	b.counts[b.keyFor(todoErr)]++
+}
+
+// Match the given todo error against the baseline. Each entry matches as many todo errors as its count
+func (b *Baseline) Match(todoErr *errors.TODO) bool {
+	k := b.keyFor(todoErr)
+	if b.matched[k] >= b.counts[k] {
+		return false
+	}
+
+	b.matched[k]++
+	return true
+}
+
+// ResetMatches, so that each entry matches as many todo errors as its count again, e.g. before re-matching a changed set of errors
+func (b *Baseline) ResetMatches() {
+	b.matched = map[key]int{}
+}
+
+// Len returns the amount of todo errors in the baseline
+func (b *Baseline) Len() int {
+	total := 0
+	for _, count := range b.counts {
+		total += count
+	}
+
+	return total
+}
+
+// Prune the todo errors, which were not matched, from the baseline, e.g. because they were fixed.
+// It returns the amount of pruned todo errors
+func (b *Baseline) Prune() int {
+	pruned := 0
+	for k, count := range b.counts {
+		pruned += count - b.matched[k]
+		if b.matched[k] == 0 {
+			delete(b.counts, k)
+		} else {
+			b.counts[k] = b.matched[k]
+		}
+	}
+
+	return pruned
+}
+
+// Save the baseline to the given file. Entries are sorted, so that the file is stable across runs
+func (b *Baseline) Save(filename string) error {
+	f := file{Errors: []*Entry{}}
+	for k, count := range b.counts {
+		f.Errors = append(f.Errors, &Entry{File: k.file, Type: k.errType, IssueID: k.issueID, Hash: k.hash, Count: count})
+	}
+
+	sort.Slice(f.Errors, func(i, j int) bool {
+		a, b := f.Errors[i], f.Errors[j]
+		if a.File != b.File {
+			return a.File < b.File
+		} else if a.Type != b.Type {
+			return a.Type < b.Type
+		} else if a.IssueID != b.IssueID {
+			return a.IssueID < b.IssueID
+		}
+
+		return a.Hash < b.Hash
+	})
+
+	bs, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal baseline: %w", err)
+	}
+
+	if err := os.WriteFile(filename, append(bs, '\n'), DefaultFilePermissions); err != nil {
+		return fmt.Errorf("failed to save baseline %s: %w", filename, err)
+	}
+
+	return nil
+}
+
+// keyFor the given todo error. Its file is relative to the basepath, so that the baseline doesn't depend on where todocheck is run from
+func (b *Baseline) keyFor(todoErr *errors.TODO) key {
+	filename := todoErr.Filename()
+	if rel, err := filepath.Rel(b.basepath, filename); err == nil {
+		filename = rel
+	}
+
+	// errors, whose comment is not known, are matched by their source code lines instead
+	text := todoErr.Comment()
+	if text == "" {
+		text = strings.Join(todoErr.Lines(), " ")
+	}
+
+	return key{filepath.ToSlash(filename), string(todoErr.Type()), todoErr.IssueID(), hashOf(text)}
+}
+
+// hashOf the given comment text, normalized so that changes in indentation & whitespace don't affect it.
+// The zero runes, which comment matchers are fed at the end of each line, are treated as whitespace
+func hashOf(text string) string {
+	normalized := strings.Join(strings.Fields(strings.ReplaceAll(text, "\x00", " ")), " ")
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:8])
+}
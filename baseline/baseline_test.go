@@ -0,0 +1,86 @@
+package baseline
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/preslavmihaylov/todocheck/checker/errors"
+)
+
+func TestMatchIgnoresLineNumbersAndWhitespace(t *testing.T) {
+	b := New("project")
+	b.Add(errors.MalformedTODOErr("project/main.go", []string{"// TODO fix this\n"}, 3))
+	b.Add(errors.IssueClosedErr("project/main.go", []string{"// TODO J123: fix this\n"}, 5, "J123"))
+
+	tests := []struct {
+		todoErr *errors.TODO
+		want    bool
+	}{
+		{errors.MalformedTODOErr("project/main.go", []string{"\t//  TODO fix this\n"}, 10), true},
+		{errors.MalformedTODOErr("project/main.go", []string{"// TODO fix this\n"}, 11), false},
+		{errors.MalformedTODOErr("project/other.go", []string{"// TODO fix that\n"}, 3), false},
+		{errors.IssueClosedErr("project/main.go", []string{"// TODO J123: fix this\n"}, 5, "J321"), false},
+		{errors.IssueNonExistentErr("project/main.go", []string{"// TODO J123: fix this\n"}, 5, "J123"), false},
+		{errors.IssueClosedErr("project/main.go", []string{"// TODO J123: fix this\n"}, 7, "J123"), true},
+	}
+
+	for _, tt := range tests {
+		if got := b.Match(tt.todoErr); got != tt.want {
+			t.Errorf("Got match %v for %q, expected %v", got, tt.todoErr, tt.want)
+		}
+	}
+}
+
+func TestMatchIgnoresCodeNextToComment(t *testing.T) {
+	b := New("project")
+	b.Add(errors.IssueClosedErr("project/main.go", []string{"x := 1 // TODO J123: fix this\n"}, 5, "J123").WithComment("// TODO J123: fix this\x00"))
+
+	tests := []struct {
+		todoErr *errors.TODO
+		want    bool
+	}{
+		{errors.IssueClosedErr("project/main.go", []string{"x := 2 // TODO J123: fix this\n"}, 5, "J123").WithComment("// TODO J123: fix this\x00"), true},
+		{errors.IssueClosedErr("project/main.go", []string{"x := 2 // TODO J123: fix that\n"}, 5, "J123").WithComment("// TODO J123: fix that\x00"), false},
+	}
+
+	for _, tt := range tests {
+		if got := b.Match(tt.todoErr); got != tt.want {
+			t.Errorf("Got match %v for %q, expected %v", got, tt.todoErr, tt.want)
+		}
+	}
+}
+
+func TestSaveLoadAndPrune(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), DefaultFile)
+	fixed := errors.MalformedTODOErr("project/main.go", []string{"// TODO fix this\n"}, 3)
+	remaining := errors.MalformedTODOErr("project/main.go", []string{"// TODO fix that\n"}, 4)
+
+	b := New("project")
+	b.Add(fixed)
+	b.Add(remaining)
+	b.Add(remaining)
+	if err := b.Save(filename); err != nil {
+		t.Fatalf("Couldn't save baseline: %v", err)
+	}
+
+	loaded, err := Load(filename, "./project/")
+	if err != nil {
+		t.Fatalf("Couldn't load baseline: %v", err)
+	}
+
+	if loaded.Len() != 3 {
+		t.Errorf("Loaded %d todo errors, expected 3", loaded.Len())
+	}
+
+	if !loaded.Match(remaining) {
+		t.Errorf("Expected %q to be in the loaded baseline", remaining)
+	}
+
+	if pruned := loaded.Prune(); pruned != 2 || loaded.Len() != 1 {
+		t.Errorf("Pruned %d todo errors, leaving %d, expected 2 & 1", pruned, loaded.Len())
+	}
+
+	if loaded.Match(fixed) {
+		t.Errorf("Expected %q to be pruned from the baseline", fixed)
+	}
+}
@@ -1,32 +1,105 @@
 package checker
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"regexp"
+	"strings"
+	"time"
 
 	checkererrors "github.com/preslavmihaylov/todocheck/checker/errors"
+	"github.com/preslavmihaylov/todocheck/config"
 	"github.com/preslavmihaylov/todocheck/issuetracker/taskstatus"
+	"github.com/preslavmihaylov/todocheck/logger"
 	"github.com/preslavmihaylov/todocheck/matchers"
 )
 
 type Fetcher interface {
-	Fetch(taskID string) (taskstatus.TaskStatus, error)
+	Fetch(ctx context.Context, taskID string) (taskstatus.Result, error)
 }
 
 // Checker for todo lines
 type Checker struct {
-	statusFetcher Fetcher
+	statusFetcher      Fetcher
+	trackers           []*tracker
+	onTrackerError     config.OnTrackerError
+	ownerPolicy        config.OwnerPolicy
+	requiredOpenIssues config.RequiredOpenIssues
+	allowedRepos       config.AllowedRepositories
+	overrides          config.Overrides
+	now                time.Time
+	failedLookups      map[string]bool
 }
 
-// New checker
-func New(statusFetcher Fetcher) *Checker {
-	return &Checker{statusFetcher}
+// New checker, configured via the on_tracker_error, owner_policy, required_open_issues & allowed_repositories options of the given configuration.
+// Todos with an expiry date are expired, if the date is before now
+func New(statusFetcher Fetcher, cfg *config.Local, now time.Time) *Checker {
+	return &Checker{
+		statusFetcher:      statusFetcher,
+		trackers:           trackersFrom(cfg),
+		onTrackerError:     cfg.OnTrackerError,
+		ownerPolicy:        cfg.OwnerPolicy,
+		requiredOpenIssues: cfg.RequiredOpenIssues,
+		allowedRepos:       cfg.AllowedRepositories,
+		overrides:          cfg.Overrides,
+		now:                now,
+		failedLookups:      map[string]bool{},
+	}
+}
+
+// NewOffline checker, which never fetches issue statuses.
+// It only checks if todos are well-formed, not expired & if their issue references are valid for the configured issue tracker
+func NewOffline(cfg *config.Local, now time.Time) *Checker {
+	return &Checker{trackers: trackersFrom(cfg), allowedRepos: cfg.AllowedRepositories, overrides: cfg.Overrides, now: now}
+}
+
+// tracker is an issue tracker, whose issues are referenced by todos
+type tracker struct {
+	// name of the issue tracker, which prefixes references to its issues. It is empty for the default issue tracker
+	name         string
+	issueTracker config.IssueTracker
+	origin       string
+	idPattern    *regexp.Regexp
+
+	// isOverride is set for issue trackers, set by overrides, which only apply to the files matching the override
+	isOverride bool
+}
+
+// trackersFrom the given configuration. The default issue tracker is first, followed by the named ones
+func trackersFrom(cfg *config.Local) []*tracker {
+	trackers := []*tracker{{issueTracker: cfg.IssueTracker, origin: cfg.Origin}}
+	for _, t := range cfg.IssueTrackers {
+		named := &tracker{name: t.Name, issueTracker: t.IssueTracker, origin: t.Origin, isOverride: t.IsOverride()}
+		if t.IDPattern != "" {
+			// invalid patterns are reported when validating the configuration
+			named.idPattern, _ = regexp.Compile(t.IDPattern)
+		}
+
+		trackers = append(trackers, named)
+	}
+
+	return trackers
+}
+
+func (t *tracker) isOriginRepo(repo string) bool {
+	return strings.HasSuffix(strings.ToLower(strings.TrimRight(t.origin, "/")), "/"+strings.ToLower(repo))
 }
 
-// Check if todo line is valid
+// ResetFailedLookups forgets the issues, whose status couldn't be fetched, e.g. before their statuses are fetched again
+func (c *Checker) ResetFailedLookups() {
+	c.failedLookups = map[string]bool{}
+}
+
+// FailedLookups returns the amount of distinct issues, whose status couldn't be fetched
+func (c *Checker) FailedLookups() int {
+	return len(c.failedLookups)
+}
+
+// Check if todo line is valid. A todo, which references multiple issues, gets a separate error for each invalid issue
 func (c *Checker) Check(
-	matcher matchers.TodoMatcher, comment, filename string, lines []string, linecnt int,
-) (*checkererrors.TODO, error) {
+	ctx context.Context, matcher matchers.TodoMatcher, comment, filename string, lines []string, linecnt int,
+) ([]*checkererrors.TODO, error) {
 	if matcher == nil {
 		return nil, errors.New("matcher is nil")
 	}
@@ -36,26 +109,313 @@ func (c *Checker) Check(
 	}
 
 	if !matcher.IsValid(comment) {
-		return checkererrors.MalformedTODOErr(filename, lines, linecnt), nil
+		return []*checkererrors.TODO{checkererrors.MalformedTODOErr(filename, lines, linecnt)}, nil
 	}
 
-	taskID, err := matcher.ExtractIssueRef(comment)
+	refs, err := c.ExtractIssueRefs(matcher, comment)
 	if err != nil {
 		// should never happen after validating todo line
 		panic("couldn't extract issue reference from a valid todo: " + err.Error())
 	}
 
-	status, err := c.statusFetcher.Fetch(taskID)
+	expiry, err := matcher.ExtractExpiry(comment)
+	if err != nil {
+		return []*checkererrors.TODO{checkererrors.MalformedTODOErr(filename, lines, linecnt)}, nil
+	} else if expiry != nil && c.isExpired(*expiry) {
+		return []*checkererrors.TODO{checkererrors.TODOExpiredErr(filename, lines, linecnt, strings.Join(refs, ", "), *expiry)}, nil
+	}
+
+	// errors for issue URLs, which don't point at the configured issue tracker, & for issues in repositories, which are not allowed,
+	// are reported regardless of the other issues
+	var refErrs []*checkererrors.TODO
+	var taskIDs []string
+	for _, ref := range refs {
+		taskID, todoErr := c.taskIDFor(ref, filename, lines, linecnt)
+		if todoErr != nil {
+			refErrs = append(refErrs, todoErr)
+			continue
+		}
+
+		taskIDs = append(taskIDs, taskID)
+	}
+
+	if c.statusFetcher == nil {
+		for _, taskID := range taskIDs {
+			if !c.isValidIssueRef(taskID) {
+				refErrs = append(refErrs, checkererrors.InvalidIssueRefErr(filename, lines, linecnt, config.DisplayTaskID(taskID)))
+			}
+		}
+
+		return refErrs, nil
+	}
+
+	var (
+		todoErrs     []*checkererrors.TODO
+		openTaskIDs  []string
+		assignees    []string
+		hasUnknown   bool
+		hasOpenIssue bool
+	)
+
+	for _, taskID := range taskIDs {
+		status, err := c.statusFetcher.Fetch(ctx, taskID)
+		if err != nil && ctx.Err() == nil && c.onTrackerError != config.OnTrackerErrorFail {
+			c.failedLookups[taskID] = true
+			hasUnknown = true
+			if c.onTrackerError == config.OnTrackerErrorWarn {
+				todoErrs = append(todoErrs, checkererrors.IssueStatusUnknownErr(filename, lines, linecnt, config.DisplayTaskID(taskID), err))
+			} else {
+				logger.Infof("Skipping issue %s of todo in %s:%d as its status couldn't be fetched: %s\n", taskID, filename, linecnt, err)
+			}
+
+			continue
+		} else if err != nil {
+			return nil, fmt.Errorf("couldn't fetch task status: %w", err)
+		}
+
+		if todoErr := issueErr(filename, lines, linecnt, taskID, status); todoErr != nil {
+			todoErrs = append(todoErrs, todoErr)
+			continue
+		}
+
+		hasOpenIssue = true
+		openTaskIDs = append(openTaskIDs, config.DisplayTaskID(taskID))
+		assignees = appendMissing(assignees, status.Assignees...)
+	}
+
+	if c.requiredOpenIssues == config.RequiredOpenIssuesAny {
+		if hasOpenIssue {
+			todoErrs = nil
+		} else if hasUnknown {
+			// any of the issues, whose status is unknown, might be open
+			todoErrs = warningsOnly(todoErrs)
+		}
+	}
+
+	if c.ownerPolicy == config.OwnerPolicyAssignee && hasOpenIssue {
+		owner, err := matcher.ExtractOwner(comment)
+		if err != nil {
+			// should never happen after validating todo line
+			panic("couldn't extract owner from a valid todo: " + err.Error())
+		}
+
+		if owner != "" && len(assignees) > 0 && !isAssignee(owner, assignees) {
+			todoErrs = append(todoErrs,
+				checkererrors.OwnerMismatchErr(filename, lines, linecnt, strings.Join(openTaskIDs, ", "), owner, assignees))
+		}
+	}
+
+	return append(refErrs, todoErrs...), nil
+}
+
+// TaskIDFor the given issue reference. Issue URLs are parsed into the task ID they point at.
+// References to issues in other repositories are checked against the allowed repositories. The origin repository is always allowed.
+// Task IDs of named issue trackers are prefixed with the tracker's name, e.g. gh:#12.
+// The default issue tracker of the file, the reference is in, is set by the override for the file, if any
+func (c *Checker) TaskIDFor(ref, filename string) (string, error) {
+	t, ref, err := c.trackerFor(ref, filename)
+	if err != nil {
+		return "", err
+	}
+
+	taskID, err := t.issueTracker.TaskIDFor(t.origin, ref)
+	if err != nil {
+		return "", err
+	}
+
+	repo, _ := config.SplitCrossRepoRef(taskID)
+	if repo != "" && !t.issueTracker.IsValidIssueRef(taskID) {
+		return "", fmt.Errorf("issue tracker %q doesn't support references to issues in another repository in this format", t.issueTracker)
+	} else if repo != "" && !c.allowedRepos.Allows(repo) && !t.isOriginRepo(repo) {
+		return "", fmt.Errorf("%w: %s", config.ErrRepoNotAllowed, repo)
+	}
+
+	return config.WithTrackerPrefix(t.name, taskID), nil
+}
+
+// WebURLFor the page of the given task, as returned by TaskIDFor, in the web UI of the issue tracker it belongs to.
+// If the issue tracker's issue URLs are not known, an empty string is returned
+func (c *Checker) WebURLFor(taskID string) string {
+	name, unprefixed := config.SplitTrackerPrefix(taskID)
+	for _, t := range c.trackers[1:] {
+		if t.name == name {
+			return t.issueTracker.WebURLFor(t.origin, unprefixed)
+		}
+	}
+
+	return c.trackers[0].issueTracker.WebURLFor(c.trackers[0].origin, taskID)
+}
+
+// ExtractIssueRefs from the given valid todo comment, using the given matcher. Todo patterns can't tell an issue tracker prefix
+// from the colon, which ends the references, e.g. in `TODO J123:note: fix`, so a prefix, which doesn't name a configured issue tracker,
+// ends the references instead & the rest of the comment is the todo's text
+func (c *Checker) ExtractIssueRefs(matcher matchers.TodoMatcher, comment string) ([]string, error) {
+	refs, err := matcher.ExtractIssueRefs(comment)
 	if err != nil {
-		return nil, fmt.Errorf("couldn't fetch task status: %w", err)
+		return nil, err
+	}
+
+	for i, ref := range refs {
+		if name, _ := config.SplitTrackerPrefix(ref); name != "" && c.prefixTrackerNamed(name) == nil {
+			return append(refs[:i:i], name), nil
+		}
+	}
+
+	return refs, nil
+}
+
+// trackerFor the given issue reference in the given file & the reference without its issue tracker prefix.
+// Issue URLs belong to the file's default issue tracker or the first other issue tracker, whose origin they point at.
+// References without a prefix belong to the first named issue tracker, whose id_pattern they match.
+// Otherwise, issue references belong to the file's default issue tracker
+func (c *Checker) trackerFor(ref, filename string) (*tracker, string, error) {
+	defaultTracker := c.defaultTrackerFor(filename)
+	named := c.trackers[1:]
+	if config.IsIssueURL(ref) {
+		for _, t := range append([]*tracker{defaultTracker}, c.trackers...) {
+			if _, err := t.issueTracker.TaskIDFor(t.origin, ref); err == nil {
+				return t, ref, nil
+			}
+		}
+
+		return defaultTracker, ref, nil
+	}
+
+	if name, unprefixed := config.SplitTrackerPrefix(ref); name != "" {
+		if t := c.prefixTrackerNamed(name); t != nil {
+			return t, unprefixed, nil
+		}
+
+		return nil, "", fmt.Errorf("unknown issue tracker %q", name)
+	}
+
+	for _, t := range named {
+		if t.idPattern != nil && t.idPattern.MatchString(ref) {
+			return t, ref, nil
+		}
+	}
+
+	return defaultTracker, ref, nil
+}
+
+// defaultTrackerFor the given file. It is the issue tracker, set by the override for the file, if any
+func (c *Checker) defaultTrackerFor(filename string) *tracker {
+	if o := c.overrides.For(filename); o != nil && o.IssueTrackerName() != "" {
+		return c.trackerNamed(o.IssueTrackerName())
+	}
+
+	return c.trackers[0]
+}
+
+// trackerNamed returns the issue tracker with the given name or nil if there is none. The default issue tracker's name is empty
+func (c *Checker) trackerNamed(name string) *tracker {
+	for _, t := range c.trackers {
+		if strings.EqualFold(t.name, name) {
+			return t
+		}
+	}
+
+	return nil
+}
+
+// prefixTrackerNamed returns the named issue tracker, which issue references can be prefixed with, or nil if there is none.
+// Issue trackers, set by overrides, can't be referred to via a prefix, as they only apply to the files matching the override
+func (c *Checker) prefixTrackerNamed(name string) *tracker {
+	if t := c.trackerNamed(name); t != nil && t.name != "" && !t.isOverride {
+		return t
 	}
 
-	switch status {
+	return nil
+}
+
+// isValidIssueRef checks if the given task ID, prefixed with its issue tracker's name, has a valid format for the issue tracker
+func (c *Checker) isValidIssueRef(taskID string) bool {
+	name, taskID := config.SplitTrackerPrefix(taskID)
+	t := c.trackerNamed(name)
+	return t != nil && t.issueTracker.IsValidIssueRef(taskID)
+}
+
+// taskIDFor the given issue reference. If the reference doesn't point at an issue, which can be checked, the todo error for it is returned
+func (c *Checker) taskIDFor(ref, filename string, lines []string, linecnt int) (string, *checkererrors.TODO) {
+	taskID, err := c.TaskIDFor(ref, filename)
+	if errors.Is(err, config.ErrIssueURLMismatch) {
+		return "", checkererrors.IssueURLMismatchErr(filename, lines, linecnt, ref, c.defaultTrackerFor(filename).origin)
+	} else if errors.Is(err, config.ErrRepoNotAllowed) {
+		_, unprefixed := config.SplitTrackerPrefix(ref)
+		repo, _ := config.SplitCrossRepoRef(unprefixed)
+		return "", checkererrors.RepoNotAllowedErr(filename, lines, linecnt, ref, repo)
+	} else if err != nil {
+		logger.Infof("Invalid issue reference %s in %s:%d: %s\n", ref, filename, linecnt, err)
+		return "", checkererrors.InvalidIssueRefErr(filename, lines, linecnt, ref)
+	}
+
+	return taskID, nil
+}
+
+// issueErr returns the error for a todo, referencing an issue with the given status, or nil if the issue is open
+func issueErr(filename string, lines []string, linecnt int, taskID string, status taskstatus.Result) *checkererrors.TODO {
+	taskID = config.DisplayTaskID(taskID)
+	status.LinkedTaskID = config.DisplayTaskID(status.LinkedTaskID)
+	switch status.Status {
 	case taskstatus.Closed:
-		return checkererrors.IssueClosedErr(filename, lines, linecnt, taskID), nil
+		return checkererrors.IssueClosedErr(filename, lines, linecnt, taskID)
+	case taskstatus.ClosedNotPlanned:
+		return checkererrors.IssueNotPlannedErr(filename, lines, linecnt, taskID)
+	case taskstatus.ClosedDuplicate:
+		return checkererrors.IssueDuplicateErr(filename, lines, linecnt, taskID, status.LinkedTaskID)
+	case taskstatus.Moved:
+		return checkererrors.IssueMovedErr(filename, lines, linecnt, taskID, status.LinkedTaskID)
 	case taskstatus.NonExistent:
-		return checkererrors.IssueNonExistentErr(filename, lines, linecnt, taskID), nil
+		return checkererrors.IssueNonExistentErr(filename, lines, linecnt, taskID)
+	}
+
+	return nil
+}
+
+// isExpired returns true if the given expiry date has passed. Todos are valid until the end of their expiry date
+func (c *Checker) isExpired(expiry time.Time) bool {
+	return !c.now.Before(expiry.AddDate(0, 0, 1))
+}
+
+// isAssignee returns true if the todo owner matches any of the given assignees, ignoring case & a leading @.
+// Owners also match the local part of an assignee's email, e.g. alice matches alice@example.com
+func isAssignee(owner string, assignees []string) bool {
+	owner = strings.TrimPrefix(owner, "@")
+	for _, assignee := range assignees {
+		localPart, _, _ := strings.Cut(assignee, "@")
+		if strings.EqualFold(owner, assignee) || strings.EqualFold(owner, localPart) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func appendMissing(values []string, newValues ...string) []string {
+	for _, newValue := range newValues {
+		isPresent := false
+		for _, value := range values {
+			if value == newValue {
+				isPresent = true
+				break
+			}
+		}
+
+		if !isPresent {
+			values = append(values, newValue)
+		}
+	}
+
+	return values
+}
+
+func warningsOnly(todoErrs []*checkererrors.TODO) []*checkererrors.TODO {
+	var warnings []*checkererrors.TODO
+	for _, todoErr := range todoErrs {
+		if todoErr.IsWarning() {
+			warnings = append(warnings, todoErr)
+		}
 	}
 
-	return nil, nil
+	return warnings
 }
@@ -1,17 +1,28 @@
 package checker
 
 import (
+	"context"
 	"errors"
+	"os"
+	"path/filepath"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	checkerrors "github.com/preslavmihaylov/todocheck/checker/errors"
+	"github.com/preslavmihaylov/todocheck/config"
 	"github.com/preslavmihaylov/todocheck/issuetracker/taskstatus"
 )
 
+var (
+	testNow    = time.Date(2026, 6, 15, 12, 0, 0, 0, time.Local)
+	testExpiry = time.Date(2026, 6, 14, 0, 0, 0, 0, time.Local)
+)
+
 func TestCheck(t *testing.T) {
 	fetcher := mockFetcher{}
-	checker := New(&fetcher)
+	checker := New(&fetcher, &config.Local{OnTrackerError: config.OnTrackerErrorFail}, testNow)
 	matcher := mockMatcher{}
 
 	testLines := []string{}
@@ -19,22 +30,30 @@ func TestCheck(t *testing.T) {
 
 	testData := []struct {
 		comment, filename string
-		todoErr           *checkerrors.TODO
+		todoErrs          []*checkerrors.TODO
 		err               error
 	}{
 		{"NotMatch", "", nil, nil},
-		{"NotValid", "test.go", checkerrors.MalformedTODOErr("test.go", testLines, testLineCnt), nil},
+		{"NotValid", "test.go", []*checkerrors.TODO{checkerrors.MalformedTODOErr("test.go", testLines, testLineCnt)}, nil},
 		{"FailedFetch", "", nil, errors.New("")},
-		{"ClosedIssue", "test.go", checkerrors.IssueClosedErr("test.go", testLines, testLineCnt, "ClosedIssue"), nil},
-		{"NonExistentIssue", "test.go", checkerrors.IssueNonExistentErr("test.go", testLines, testLineCnt, "NonExistentIssue"), nil},
+		{"ClosedIssue", "test.go", []*checkerrors.TODO{checkerrors.IssueClosedErr("test.go", testLines, testLineCnt, "ClosedIssue")}, nil},
+		{"NonExistentIssue", "test.go", []*checkerrors.TODO{checkerrors.IssueNonExistentErr("test.go", testLines, testLineCnt, "NonExistentIssue")}, nil},
+		{"NotPlannedIssue", "test.go", []*checkerrors.TODO{checkerrors.IssueNotPlannedErr("test.go", testLines, testLineCnt, "NotPlannedIssue")}, nil},
+		{"DuplicateIssue", "test.go", []*checkerrors.TODO{checkerrors.IssueDuplicateErr("test.go", testLines, testLineCnt, "DuplicateIssue", "OriginalIssue")}, nil},
+		{"UnlinkedDuplicateIssue", "test.go", []*checkerrors.TODO{checkerrors.IssueDuplicateErr("test.go", testLines, testLineCnt, "UnlinkedDuplicateIssue", "")}, nil},
+		{"ExpiredTodo", "test.go", []*checkerrors.TODO{checkerrors.TODOExpiredErr("test.go", testLines, testLineCnt, "ExpiredTodo", testExpiry)}, nil},
+		{"ExpiringTodo", "", nil, nil},
+		{"InvalidExpiry", "test.go", []*checkerrors.TODO{checkerrors.MalformedTODOErr("test.go", testLines, testLineCnt)}, nil},
+		{"MovedIssue", "test.go", []*checkerrors.TODO{checkerrors.IssueMovedErr("test.go", testLines, testLineCnt, "MovedIssue", "NewIssue")}, nil},
+		{"UnlinkedMovedIssue", "test.go", []*checkerrors.TODO{checkerrors.IssueMovedErr("test.go", testLines, testLineCnt, "UnlinkedMovedIssue", "")}, nil},
 		{"Valid", "", nil, nil},
 	}
 	for _, tt := range testData {
 		t.Run(tt.comment, func(t *testing.T) {
 
-			todoErr, err := checker.Check(matcher, tt.comment, tt.filename, testLines, testLineCnt)
-			if !reflect.DeepEqual(todoErr, tt.todoErr) {
-				t.Errorf("Expected toddErr to be %v, got %v", tt.todoErr, todoErr)
+			todoErrs, err := checker.Check(context.Background(), matcher, tt.comment, tt.filename, testLines, testLineCnt)
+			if !reflect.DeepEqual(todoErrs, tt.todoErrs) {
+				t.Errorf("Expected todoErrs to be %v, got %v", tt.todoErrs, todoErrs)
 			}
 			if (err == nil) != (tt.err == nil) { // Don't care about the error string
 				t.Errorf("Expected err to be %v, got %v", tt.err, err)
@@ -43,9 +62,9 @@ func TestCheck(t *testing.T) {
 	}
 
 	t.Run("NilMatcher", func(t *testing.T) {
-		todoErr, err := checker.Check(nil, "", "", testLines, testLineCnt)
-		if todoErr != nil {
-			t.Errorf("Expected toddErr to be nil, got %v", todoErr)
+		todoErrs, err := checker.Check(context.Background(), nil, "", "", testLines, testLineCnt)
+		if todoErrs != nil {
+			t.Errorf("Expected todoErrs to be nil, got %v", todoErrs)
 		}
 		if err == nil { // Don't care about the error string
 			t.Errorf("Expected err to be not nil")
@@ -57,7 +76,7 @@ func TestCheck(t *testing.T) {
 				t.Logf("Recovered in %v", r)
 			}
 		}()
-		_, err := checker.Check(matcher, "InvalidExtract", "", testLines, testLineCnt)
+		_, err := checker.Check(context.Background(), matcher, "InvalidExtract", "", testLines, testLineCnt)
 		if err != nil {
 			t.Errorf("Expected err to be nil, got %v", err)
 		}
@@ -65,6 +84,356 @@ func TestCheck(t *testing.T) {
 	})
 }
 
+func TestCheckOnTrackerError(t *testing.T) {
+	testLines := []string{}
+	testLineCnt := 0
+
+	testData := []struct {
+		policy   config.OnTrackerError
+		todoErrs []*checkerrors.TODO
+	}{
+		{config.OnTrackerErrorWarn, []*checkerrors.TODO{checkerrors.IssueStatusUnknownErr("test.go", testLines, testLineCnt, "FailedFetch", errors.New("FailedFetch"))}},
+		{config.OnTrackerErrorSkip, nil},
+	}
+	for _, tt := range testData {
+		t.Run(string(tt.policy), func(t *testing.T) {
+			checker := New(&mockFetcher{}, &config.Local{OnTrackerError: tt.policy}, testNow)
+			for i := 0; i < 2; i++ {
+				todoErrs, err := checker.Check(context.Background(), mockMatcher{}, "FailedFetch", "test.go", testLines, testLineCnt)
+				if !reflect.DeepEqual(todoErrs, tt.todoErrs) {
+					t.Errorf("Expected todoErrs to be %v, got %v", tt.todoErrs, todoErrs)
+				}
+				if err != nil {
+					t.Errorf("Expected err to be nil, got %v", err)
+				}
+			}
+
+			if checker.FailedLookups() != 1 {
+				t.Errorf("Expected 1 failed lookup, got %d", checker.FailedLookups())
+			}
+		})
+	}
+
+	t.Run("CancelledContext", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		checker := New(&mockFetcher{}, &config.Local{OnTrackerError: config.OnTrackerErrorWarn}, testNow)
+		if _, err := checker.Check(ctx, mockMatcher{}, "FailedFetch", "test.go", testLines, testLineCnt); err == nil {
+			t.Errorf("Expected err to be not nil")
+		}
+	})
+}
+
+func TestCheckOwnerPolicy(t *testing.T) {
+	testLines := []string{}
+	testLineCnt := 0
+
+	testData := []struct {
+		policy   config.OwnerPolicy
+		comment  string
+		todoErrs []*checkerrors.TODO
+	}{
+		{config.OwnerPolicyAssignee, "OwnedAssignedIssue", nil},
+		{config.OwnerPolicyAssignee, "OwnedUnassignedIssue", nil},
+		{config.OwnerPolicyAssignee, "OwnedOtherIssue,OwnedAssignedIssue", nil},
+		{config.OwnerPolicyAssignee, "OwnedOtherIssue",
+			[]*checkerrors.TODO{checkerrors.OwnerMismatchErr("test.go", testLines, testLineCnt, "OwnedOtherIssue", "@alice", []string{"bob", "carol"})}},
+		{config.OwnerPolicyAssignee, "ClosedIssue", []*checkerrors.TODO{checkerrors.IssueClosedErr("test.go", testLines, testLineCnt, "ClosedIssue")}},
+		{config.OwnerPolicyAssignee, "Valid", nil},
+		{config.OwnerPolicyIgnore, "OwnedOtherIssue", nil},
+	}
+	for _, tt := range testData {
+		t.Run(string(tt.policy)+"/"+tt.comment, func(t *testing.T) {
+			checker := New(&mockFetcher{}, &config.Local{OnTrackerError: config.OnTrackerErrorFail, OwnerPolicy: tt.policy}, testNow)
+			todoErrs, err := checker.Check(context.Background(), mockMatcher{}, tt.comment, "test.go", testLines, testLineCnt)
+			if !reflect.DeepEqual(todoErrs, tt.todoErrs) {
+				t.Errorf("Expected todoErrs to be %v, got %v", tt.todoErrs, todoErrs)
+			}
+			if err != nil {
+				t.Errorf("Expected err to be nil, got %v", err)
+			}
+		})
+	}
+}
+
+func TestCheckMultipleIssues(t *testing.T) {
+	testLines := []string{}
+	testLineCnt := 0
+
+	testData := []struct {
+		policy   config.RequiredOpenIssues
+		comment  string
+		todoErrs []*checkerrors.TODO
+	}{
+		{config.RequiredOpenIssuesAll, "Valid,OpenIssue", nil},
+		{config.RequiredOpenIssuesAll, "ClosedIssue,Valid", []*checkerrors.TODO{
+			checkerrors.IssueClosedErr("test.go", testLines, testLineCnt, "ClosedIssue"),
+		}},
+		{config.RequiredOpenIssuesAll, "ClosedIssue,NonExistentIssue", []*checkerrors.TODO{
+			checkerrors.IssueClosedErr("test.go", testLines, testLineCnt, "ClosedIssue"),
+			checkerrors.IssueNonExistentErr("test.go", testLines, testLineCnt, "NonExistentIssue"),
+		}},
+		{config.RequiredOpenIssuesAny, "ClosedIssue,Valid", nil},
+		{config.RequiredOpenIssuesAny, "ClosedIssue,NonExistentIssue", []*checkerrors.TODO{
+			checkerrors.IssueClosedErr("test.go", testLines, testLineCnt, "ClosedIssue"),
+			checkerrors.IssueNonExistentErr("test.go", testLines, testLineCnt, "NonExistentIssue"),
+		}},
+		{config.RequiredOpenIssuesAny, "ClosedIssue,FailedFetch", []*checkerrors.TODO{
+			checkerrors.IssueStatusUnknownErr("test.go", testLines, testLineCnt, "FailedFetch", errors.New("FailedFetch")),
+		}},
+		{config.RequiredOpenIssuesAny, "Valid,https://github.com/user/other-repo/issues/1", []*checkerrors.TODO{
+			checkerrors.IssueURLMismatchErr("test.go", testLines, testLineCnt, "https://github.com/user/other-repo/issues/1", "github.com/user/repo"),
+		}},
+		{config.RequiredOpenIssuesAll, "ClosedIssue,FailedFetch", []*checkerrors.TODO{
+			checkerrors.IssueClosedErr("test.go", testLines, testLineCnt, "ClosedIssue"),
+			checkerrors.IssueStatusUnknownErr("test.go", testLines, testLineCnt, "FailedFetch", errors.New("FailedFetch")),
+		}},
+	}
+	for _, tt := range testData {
+		t.Run(string(tt.policy)+"/"+tt.comment, func(t *testing.T) {
+			cfg := &config.Local{
+				IssueTracker:       config.IssueTrackerGithub,
+				Origin:             "github.com/user/repo",
+				OnTrackerError:     config.OnTrackerErrorWarn,
+				RequiredOpenIssues: tt.policy,
+			}
+			checker := New(&mockFetcher{}, cfg, testNow)
+			todoErrs, err := checker.Check(context.Background(), mockMatcher{}, tt.comment, "test.go", testLines, testLineCnt)
+			if !reflect.DeepEqual(todoErrs, tt.todoErrs) {
+				t.Errorf("Expected todoErrs to be %v, got %v", tt.todoErrs, todoErrs)
+			}
+			if err != nil {
+				t.Errorf("Expected err to be nil, got %v", err)
+			}
+		})
+	}
+}
+
+func TestCheckCrossRepoRefs(t *testing.T) {
+	testLines := []string{}
+	testLineCnt := 0
+
+	testData := []struct {
+		issueTracker config.IssueTracker
+		comment      string
+		todoErrs     []*checkerrors.TODO
+	}{
+		{config.IssueTrackerGithub, "org/lib#1", nil},
+		{config.IssueTrackerGithub, "Org/Other-Lib#2,#3", nil},
+		{config.IssueTrackerGithub, "User/Repo#4", nil},
+		{config.IssueTrackerGithub, "other/lib#5,#6", []*checkerrors.TODO{
+			checkerrors.RepoNotAllowedErr("test.go", testLines, testLineCnt, "other/lib#5", "other/lib"),
+		}},
+		{config.IssueTrackerJira, "org/lib#7", []*checkerrors.TODO{
+			checkerrors.InvalidIssueRefErr("test.go", testLines, testLineCnt, "org/lib#7"),
+		}},
+	}
+	for _, tt := range testData {
+		t.Run(string(tt.issueTracker)+"/"+tt.comment, func(t *testing.T) {
+			checker := NewOffline(&config.Local{
+				IssueTracker:        tt.issueTracker,
+				Origin:              "github.com/user/repo",
+				AllowedRepositories: config.AllowedRepositories{"org/*"},
+			}, testNow)
+			todoErrs, err := checker.Check(context.Background(), mockMatcher{}, tt.comment, "test.go", testLines, testLineCnt)
+			if !reflect.DeepEqual(todoErrs, tt.todoErrs) {
+				t.Errorf("Expected todoErrs to be %v, got %v", tt.todoErrs, todoErrs)
+			}
+			if err != nil {
+				t.Errorf("Expected err to be nil, got %v", err)
+			}
+		})
+	}
+}
+
+func TestTaskIDForNamedIssueTrackers(t *testing.T) {
+	checker := NewOffline(&config.Local{
+		IssueTracker: config.IssueTrackerJira,
+		Origin:       "https://myorg.atlassian.net",
+		IssueTrackers: []*config.NamedIssueTracker{
+			{Name: "gh", IssueTracker: config.IssueTrackerGithub, Origin: "github.com/user/repo", IDPattern: `^#[0-9]+$`},
+			{Name: "gl", IssueTracker: config.IssueTrackerGitlab, Origin: "gitlab.com/group/project"},
+		},
+	}, testNow)
+
+	testData := []struct {
+		ref     string
+		want    string
+		wantErr bool
+	}{
+		{"ABC-12", "ABC-12", false},
+		{"gh:#12", "gh:#12", false},
+		{"GH:org/lib#3", "gh:org/lib#3", false},
+		{"#12", "gh:#12", false},
+		{"gl:12", "gl:12", false},
+		{"https://gitlab.com/group/project/-/issues/7", "gl:#7", false},
+		{"https://myorg.atlassian.net/browse/ABC-12", "ABC-12", false},
+		{"yt:ABC-12", "", true},
+	}
+	for _, tt := range testData {
+		taskID, err := checker.TaskIDFor(tt.ref, "test.go")
+		if taskID != tt.want {
+			t.Errorf("Expected task ID for %s to be %s, got %s", tt.ref, tt.want, taskID)
+		}
+		if (err != nil) != tt.wantErr {
+			t.Errorf("Expected error for %s: %v, got %v", tt.ref, tt.wantErr, err)
+		}
+	}
+
+	todoErrs, err := checker.Check(context.Background(), mockMatcher{}, "gh:J-1,ABC-12", "test.go", nil, 0)
+	want := []*checkerrors.TODO{checkerrors.InvalidIssueRefErr("test.go", nil, 0, "gh:J-1")}
+	if !reflect.DeepEqual(todoErrs, want) {
+		t.Errorf("Expected todoErrs to be %v, got %v", want, todoErrs)
+	}
+	if err != nil {
+		t.Errorf("Expected err to be nil, got %v", err)
+	}
+}
+
+func TestExtractIssueRefsWithTrackerPrefixes(t *testing.T) {
+	checker := NewOffline(&config.Local{
+		IssueTracker: config.IssueTrackerJira,
+		Origin:       "https://myorg.atlassian.net",
+		IssueTrackers: []*config.NamedIssueTracker{
+			{Name: "gh", IssueTracker: config.IssueTrackerGithub, Origin: "github.com/user/repo"},
+		},
+	}, testNow)
+
+	testData := []struct {
+		comment string
+		want    []string
+	}{
+		{"ABC-1:note", []string{"ABC-1"}},
+		{"ABC-1,ABC-2:note", []string{"ABC-1", "ABC-2"}},
+		{"ABC-1:note,ABC-2", []string{"ABC-1"}},
+		{"gh:#12,ABC-1", []string{"gh:#12", "ABC-1"}},
+		{"GH:#12", []string{"GH:#12"}},
+	}
+	for _, tt := range testData {
+		refs, err := checker.ExtractIssueRefs(mockMatcher{}, tt.comment)
+		if !reflect.DeepEqual(refs, tt.want) {
+			t.Errorf("Expected issue references in %s to be %v, got %v", tt.comment, tt.want, refs)
+		}
+		if err != nil {
+			t.Errorf("Expected err to be nil, got %v", err)
+		}
+	}
+}
+
+func TestTaskIDForOverrides(t *testing.T) {
+	dir := t.TempDir()
+	cfgPath := filepath.Join(dir, ".todocheck.yaml")
+	cfgContent := "issue_tracker: GITHUB\norigin: github.com/user/repo\n" +
+		"overrides:\n  payments:\n    issue_tracker: JIRA\n    origin: https://myorg.atlassian.net\n"
+	if err := os.WriteFile(cfgPath, []byte(cfgContent), 0644); err != nil {
+		t.Fatalf("Couldn't write configuration: %v", err)
+	}
+
+	cfg, err := config.NewOfflineLocal(cfgPath, dir)
+	if err != nil {
+		t.Fatalf("Couldn't read configuration: %v", err)
+	}
+
+	checker := NewOffline(cfg, testNow)
+	testData := []struct {
+		ref, filename string
+		want          string
+		wantErr       bool
+	}{
+		{"#12", "main.go", "#12", false},
+		{"ABC-12", "payments/main.go", "_override0:ABC-12", false},
+		{"ABC-12", "payments/api/main.go", "_override0:ABC-12", false},
+		{"org/lib#3", "main.go", "org/lib#3", false},
+		{"org/lib#3", "payments/main.go", "", true},
+		{"https://github.com/user/repo/issues/12", "payments/main.go", "#12", false},
+		{"https://myorg.atlassian.net/browse/ABC-12", "main.go", "_override0:ABC-12", false},
+		{"https://gitlab.com/group/project/-/issues/7", "payments/main.go", "", true},
+		{"_override0:ABC-12", "main.go", "", true},
+		{"_override0:ABC-12", "payments/main.go", "", true},
+	}
+	for _, tt := range testData {
+		taskID, err := checker.TaskIDFor(tt.ref, tt.filename)
+		if taskID != tt.want {
+			t.Errorf("Expected task ID for %s in %s to be %s, got %s", tt.ref, tt.filename, tt.want, taskID)
+		}
+		if (err != nil) != tt.wantErr {
+			t.Errorf("Expected error for %s in %s: %v, got %v", tt.ref, tt.filename, tt.wantErr, err)
+		}
+	}
+
+	for taskID, want := range map[string]string{
+		"#12":               "https://github.com/user/repo/issues/12",
+		"_override0:ABC-12": "https://myorg.atlassian.net/browse/ABC-12",
+	} {
+		if got := checker.WebURLFor(taskID); got != want {
+			t.Errorf("Expected web URL for %s to be %s, got %s", taskID, want, got)
+		}
+	}
+
+	todoErrs, err := checker.Check(context.Background(), mockMatcher{}, "ABC-12,ABC-13", "main.go", nil, 0)
+	want := []*checkerrors.TODO{
+		checkerrors.InvalidIssueRefErr("main.go", nil, 0, "ABC-12"),
+		checkerrors.InvalidIssueRefErr("main.go", nil, 0, "ABC-13"),
+	}
+	if !reflect.DeepEqual(todoErrs, want) {
+		t.Errorf("Expected todoErrs to be %v, got %v", want, todoErrs)
+	}
+	if err != nil {
+		t.Errorf("Expected err to be nil, got %v", err)
+	}
+
+	todoErrs, err = checker.Check(context.Background(), mockMatcher{}, "ABC-12,ABC-13", "payments/main.go", nil, 0)
+	want = nil
+	if !reflect.DeepEqual(todoErrs, want) {
+		t.Errorf("Expected todoErrs to be %v, got %v", want, todoErrs)
+	}
+	if err != nil {
+		t.Errorf("Expected err to be nil, got %v", err)
+	}
+}
+
+func TestOfflineCheck(t *testing.T) {
+	checker := NewOffline(&config.Local{IssueTracker: config.IssueTrackerGithub, Origin: "github.com/user/repo"}, testNow)
+	matcher := mockMatcher{}
+
+	testLines := []string{}
+	testLineCnt := 0
+
+	testData := []struct {
+		comment, filename string
+		todoErrs          []*checkerrors.TODO
+	}{
+		{"NotMatch", "", nil},
+		{"NotValid", "test.go", []*checkerrors.TODO{checkerrors.MalformedTODOErr("test.go", testLines, testLineCnt)}},
+		{"123", "", nil},
+		{"#123", "", nil},
+		{"J123", "test.go", []*checkerrors.TODO{checkerrors.InvalidIssueRefErr("test.go", testLines, testLineCnt, "J123")}},
+		{"https://github.com/user/repo/issues/12", "", nil},
+		{"https://github.com/user/other-repo/issues/12", "test.go", []*checkerrors.TODO{
+			checkerrors.IssueURLMismatchErr("test.go", testLines, testLineCnt, "https://github.com/user/other-repo/issues/12", "github.com/user/repo"),
+		}},
+		{"https://github.com/user/repo/wiki", "test.go", []*checkerrors.TODO{
+			checkerrors.InvalidIssueRefErr("test.go", testLines, testLineCnt, "https://github.com/user/repo/wiki"),
+		}},
+		{"J123,#123,J321", "test.go", []*checkerrors.TODO{
+			checkerrors.InvalidIssueRefErr("test.go", testLines, testLineCnt, "J123"),
+			checkerrors.InvalidIssueRefErr("test.go", testLines, testLineCnt, "J321"),
+		}},
+	}
+	for _, tt := range testData {
+		t.Run(tt.comment, func(t *testing.T) {
+			todoErrs, err := checker.Check(context.Background(), matcher, tt.comment, tt.filename, testLines, testLineCnt)
+			if !reflect.DeepEqual(todoErrs, tt.todoErrs) {
+				t.Errorf("Expected todoErrs to be %v, got %v", tt.todoErrs, todoErrs)
+			}
+			if err != nil {
+				t.Errorf("Expected err to be nil, got %v", err)
+			}
+		})
+	}
+}
+
 type mockMatcher struct {
 }
 
@@ -74,25 +443,58 @@ func (m mockMatcher) IsMatch(expr string) bool {
 func (m mockMatcher) IsValid(expr string) bool {
 	return expr != "NotValid"
 }
-func (m mockMatcher) ExtractIssueRef(expr string) (string, error) {
+func (m mockMatcher) ExtractIssueRefs(expr string) ([]string, error) {
 	if expr == "InvalidExtract" {
-		return expr, errors.New("Invalid todo")
+		return nil, errors.New("Invalid todo")
 	}
-	return expr, nil
+	return strings.Split(expr, ","), nil
+}
+func (m mockMatcher) ExtractExpiry(expr string) (*time.Time, error) {
+	switch expr {
+	case "ExpiredTodo":
+		return &testExpiry, nil
+	case "ExpiringTodo":
+		expiry := time.Date(2026, 6, 15, 0, 0, 0, 0, time.Local)
+		return &expiry, nil
+	case "InvalidExpiry":
+		return nil, errors.New("Invalid expiry date")
+	}
+	return nil, nil
+}
+
+func (m mockMatcher) ExtractOwner(expr string) (string, error) {
+	if strings.HasPrefix(expr, "Owned") {
+		return "@alice", nil
+	}
+	return "", nil
 }
 
 type mockFetcher struct {
 }
 
-func (f *mockFetcher) Fetch(taskID string) (taskstatus.TaskStatus, error) {
-	if taskID == "FailedFetch" {
-		return 0, errors.New("FailedFetch")
-	}
-	if taskID == "ClosedIssue" {
-		return 2, nil
+func (f *mockFetcher) Fetch(ctx context.Context, taskID string) (taskstatus.Result, error) {
+	switch taskID {
+	case "FailedFetch":
+		return taskstatus.Result{}, errors.New("FailedFetch")
+	case "ClosedIssue":
+		return taskstatus.Result{Status: taskstatus.Closed}, nil
+	case "NonExistentIssue":
+		return taskstatus.Result{Status: taskstatus.NonExistent}, nil
+	case "NotPlannedIssue":
+		return taskstatus.Result{Status: taskstatus.ClosedNotPlanned}, nil
+	case "DuplicateIssue":
+		return taskstatus.Result{Status: taskstatus.ClosedDuplicate, LinkedTaskID: "OriginalIssue"}, nil
+	case "UnlinkedDuplicateIssue":
+		return taskstatus.Result{Status: taskstatus.ClosedDuplicate}, nil
+	case "MovedIssue":
+		return taskstatus.Result{Status: taskstatus.Moved, LinkedTaskID: "NewIssue"}, nil
+	case "UnlinkedMovedIssue":
+		return taskstatus.Result{Status: taskstatus.Moved}, nil
+	case "OwnedAssignedIssue":
+		return taskstatus.Result{Status: taskstatus.Open, Assignees: []string{"bob", "Alice@example.com"}}, nil
+	case "OwnedOtherIssue":
+		return taskstatus.Result{Status: taskstatus.Open, Assignees: []string{"bob", "carol"}}, nil
 	}
-	if taskID == "NonExistentIssue" {
-		return 3, nil
-	}
-	return 0, nil
+
+	return taskstatus.Result{}, nil
 }
@@ -3,6 +3,8 @@ package errors
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/fatih/color"
 )
@@ -12,18 +14,37 @@ type TODOErrType string
 
 // supported todo error types enum
 const (
-	TODOErrTypeMalformed        TODOErrType = "Malformed todo"
-	TODOErrTypeIssueClosed      TODOErrType = "Issue is closed"
-	TODOErrTypeNonExistentIssue TODOErrType = "Issue doesn't exist"
+	TODOErrTypeMalformed         TODOErrType = "Malformed todo"
+	TODOErrTypeIssueClosed       TODOErrType = "Issue is closed"
+	TODOErrTypeNonExistentIssue  TODOErrType = "Issue doesn't exist"
+	TODOErrTypeInvalidIssueRef   TODOErrType = "Invalid issue reference"
+	TODOErrTypeIssueNotPlanned   TODOErrType = "Issue is closed as not planned"
+	TODOErrTypeIssueDuplicate    TODOErrType = "Issue is closed as duplicate"
+	TODOErrTypeIssueMoved        TODOErrType = "Issue was moved"
+	TODOErrTypeExpired           TODOErrType = "Expired todo"
+	TODOErrTypeOwnerMismatch     TODOErrType = "Todo owner is not an assignee"
+	TODOErrTypeIssueURLMismatch  TODOErrType = "Issue URL doesn't match origin"
+	TODOErrTypeRepoNotAllowed    TODOErrType = "Issue repository is not allowed"
+	TODOErrTypeUnusedSuppression TODOErrType = "Unused suppression"
+
+	// TODOErrTypeIssueStatusUnknown is a warning, which doesn't fail the check
+	TODOErrTypeIssueStatusUnknown TODOErrType = "Issue status unknown"
 )
 
+// malformedMessage explains the pattern, a malformed todo should match
+const malformedMessage = "TODO should match pattern - TODO {task_id}:"
+
 // TODO encapsulates the todo error information
 type TODO struct {
 	errType  TODOErrType
 	filename string
 	lines    []string
 	linecnt  int
+	message  string
 	metadata map[string]string
+
+	// comment is the text of the todo comment, the error is about. It is empty if it's not known
+	comment string
 }
 
 // ToJSON converts the todo error into json format
@@ -38,15 +59,66 @@ func (err *TODO) ToJSON() ([]byte, error) {
 		Type:     string(err.errType),
 		Filename: err.filename,
 		Line:     err.linecnt,
-		Message:  "",
+		Message:  err.Message(),
 		Metadata: err.metadata,
 	}
 
+	return json.Marshal(res)
+}
+
+// Type of the todo error
+func (err *TODO) Type() TODOErrType {
+	return err.errType
+}
+
+// Filename of the file, the todo is in
+func (err *TODO) Filename() string {
+	return err.filename
+}
+
+// Lines of the todo's source code
+func (err *TODO) Lines() []string {
+	return err.lines
+}
+
+// Comment of the todo, the error is about, without the code around it on the same lines. It is empty if it's not known
+func (err *TODO) Comment() string {
+	return err.comment
+}
+
+// WithComment sets the text of the todo comment, the error is about & returns the error
+func (err *TODO) WithComment(comment string) *TODO {
+	err.comment = comment
+	return err
+}
+
+// Line of the todo's first line of source code
+func (err *TODO) Line() int {
+	return err.linecnt
+}
+
+// Message explaining the todo error. It can be empty for errors, whose type is self-explanatory
+func (err *TODO) Message() string {
 	if err.errType == TODOErrTypeMalformed {
-		res.Message = "TODO should match pattern - TODO {task_id}:"
+		return malformedMessage
 	}
 
-	return json.Marshal(res)
+	return err.message
+}
+
+// IssueID of the issue, the todo error is about. It is empty for errors, which are not about an issue, e.g. malformed todos
+func (err *TODO) IssueID() string {
+	return err.metadata["issueID"]
+}
+
+// IsWarning returns true if the todo error shouldn't fail the check
+func (err *TODO) IsWarning() bool {
+	return err.errType.IsWarning()
+}
+
+// IsWarning returns true if todo errors of the given type shouldn't fail the check
+func (t TODOErrType) IsWarning() bool {
+	return t == TODOErrTypeIssueStatusUnknown
 }
 
 func (err *TODO) Error() string {
@@ -54,10 +126,16 @@ func (err *TODO) Error() string {
 }
 
 func (err *TODO) String() string {
-	msg := color.RedString("ERROR: " + string(err.errType) + "\n")
+	var msg string
+	if err.IsWarning() {
+		msg = color.YellowString("WARNING: " + string(err.errType) + "\n")
+	} else {
+		msg = color.RedString("ERROR: " + string(err.errType) + "\n")
+	}
+
 	msg += printSourceLocation(err.filename, err.lines, err.linecnt)
-	if err.errType == TODOErrTypeMalformed {
-		msg += color.CyanString("\t> TODO should match pattern - TODO {task_id}:\n")
+	if message := err.Message(); message != "" {
+		msg += color.CyanString("\t> " + message + "\n")
 	}
 
 	return msg
@@ -100,6 +178,177 @@ func IssueNonExistentErr(filename string, lines []string, linecnt int, issueID s
 	}
 }
 
+// IssueNotPlannedErr when referenced todo issue was closed without being done, e.g. as won't fix
+func IssueNotPlannedErr(filename string, lines []string, linecnt int, issueID string) *TODO {
+	return &TODO{
+		errType:  TODOErrTypeIssueNotPlanned,
+		filename: filename,
+		lines:    lines,
+		linecnt:  linecnt,
+		message:  fmt.Sprintf("issue %s was closed as not planned, remove your TODO or re-link it", issueID),
+		metadata: map[string]string{
+			"issueID": issueID,
+		},
+	}
+}
+
+// IssueDuplicateErr when referenced todo issue was closed as a duplicate.
+// originalIssueID is the issue it duplicates & is empty if the issue tracker doesn't specify it
+func IssueDuplicateErr(filename string, lines []string, linecnt int, issueID, originalIssueID string) *TODO {
+	err := &TODO{
+		errType:  TODOErrTypeIssueDuplicate,
+		filename: filename,
+		lines:    lines,
+		linecnt:  linecnt,
+		message:  fmt.Sprintf("issue %s was closed as duplicate, re-link your TODO to the original issue", issueID),
+		metadata: map[string]string{
+			"issueID": issueID,
+		},
+	}
+
+	if originalIssueID != "" {
+		err.message = fmt.Sprintf("issue %s was closed as duplicate of %s, re-link your TODO", issueID, originalIssueID)
+		err.metadata["duplicateOf"] = originalIssueID
+	}
+
+	return err
+}
+
+// IssueMovedErr when referenced todo issue was moved or transferred to another project.
+// newIssueID is the issue's new reference & is empty if the issue tracker doesn't specify it
+func IssueMovedErr(filename string, lines []string, linecnt int, issueID, newIssueID string) *TODO {
+	err := &TODO{
+		errType:  TODOErrTypeIssueMoved,
+		filename: filename,
+		lines:    lines,
+		linecnt:  linecnt,
+		message:  fmt.Sprintf("issue %s was moved to another project, re-link your TODO", issueID),
+		metadata: map[string]string{
+			"issueID": issueID,
+		},
+	}
+
+	if newIssueID != "" {
+		err.message = fmt.Sprintf("issue %s was moved to %s, re-link your TODO", issueID, newIssueID)
+		err.metadata["movedTo"] = newIssueID
+	}
+
+	return err
+}
+
+// TODOExpiredErr when the todo's expiry date has passed, regardless of the referenced issue's status
+func TODOExpiredErr(filename string, lines []string, linecnt int, issueID string, expiry time.Time) *TODO {
+	until := expiry.Format("2006-01-02")
+	return &TODO{
+		errType:  TODOErrTypeExpired,
+		filename: filename,
+		lines:    lines,
+		linecnt:  linecnt,
+		message:  fmt.Sprintf("todo expired after %s, resolve it or extend its deadline", until),
+		metadata: map[string]string{
+			"issueID": issueID,
+			"until":   until,
+		},
+	}
+}
+
+// OwnerMismatchErr when the todo's owner is not among the assignees of the referenced issue
+func OwnerMismatchErr(filename string, lines []string, linecnt int, issueID, owner string, assignees []string) *TODO {
+	return &TODO{
+		errType:  TODOErrTypeOwnerMismatch,
+		filename: filename,
+		lines:    lines,
+		linecnt:  linecnt,
+		message: fmt.Sprintf("todo owner %s is not assigned to issue %s, which is assigned to %s",
+			owner, issueID, strings.Join(assignees, ", ")),
+		metadata: map[string]string{
+			"issueID":   issueID,
+			"owner":     owner,
+			"assignees": strings.Join(assignees, ","),
+		},
+	}
+}
+
+// InvalidIssueRefErr when the referenced issue's format is not valid for the configured issue tracker
+func InvalidIssueRefErr(filename string, lines []string, linecnt int, issueID string) *TODO {
+	return &TODO{
+		errType:  TODOErrTypeInvalidIssueRef,
+		filename: filename,
+		lines:    lines,
+		linecnt:  linecnt,
+		metadata: map[string]string{
+			"issueID": issueID,
+		},
+	}
+}
+
+// IssueURLMismatchErr when the referenced issue URL points at a different host or repository than the configured origin
+func IssueURLMismatchErr(filename string, lines []string, linecnt int, issueURL, origin string) *TODO {
+	return &TODO{
+		errType:  TODOErrTypeIssueURLMismatch,
+		filename: filename,
+		lines:    lines,
+		linecnt:  linecnt,
+		message:  fmt.Sprintf("issue %s doesn't belong to %s", issueURL, origin),
+		metadata: map[string]string{
+			"issueID": issueURL,
+			"origin":  origin,
+		},
+	}
+}
+
+// RepoNotAllowedErr when the referenced issue belongs to a repository, which is not among the allowed repositories
+func RepoNotAllowedErr(filename string, lines []string, linecnt int, issueID, repo string) *TODO {
+	return &TODO{
+		errType:  TODOErrTypeRepoNotAllowed,
+		filename: filename,
+		lines:    lines,
+		linecnt:  linecnt,
+		message:  fmt.Sprintf("issue %s belongs to repository %s, which is not in allowed_repositories", issueID, repo),
+		metadata: map[string]string{
+			"issueID":    issueID,
+			"repository": repo,
+		},
+	}
+}
+
+// UnusedSuppressionErr when a suppression directive doesn't suppress any todo errors.
+// expiry is the date, after which the directive expired, & is nil if the directive hasn't expired
+func UnusedSuppressionErr(filename string, lines []string, linecnt int, directive string, expiry *time.Time) *TODO {
+	err := &TODO{
+		errType:  TODOErrTypeUnusedSuppression,
+		filename: filename,
+		lines:    lines,
+		linecnt:  linecnt,
+		message:  fmt.Sprintf("%s doesn't suppress any todo errors, remove it", directive),
+		metadata: map[string]string{
+			"directive": directive,
+		},
+	}
+
+	if expiry != nil {
+		until := expiry.Format("2006-01-02")
+		err.message = fmt.Sprintf("%s expired after %s & no longer suppresses todo errors, remove it", directive, until)
+		err.metadata["until"] = until
+	}
+
+	return err
+}
+
+// IssueStatusUnknownErr when the referenced issue's status couldn't be fetched from the issue tracker
+func IssueStatusUnknownErr(filename string, lines []string, linecnt int, issueID string, fetchErr error) *TODO {
+	return &TODO{
+		errType:  TODOErrTypeIssueStatusUnknown,
+		filename: filename,
+		lines:    lines,
+		linecnt:  linecnt,
+		message:  "couldn't fetch issue status: " + fetchErr.Error(),
+		metadata: map[string]string{
+			"issueID": issueID,
+		},
+	}
+}
+
 func printSourceLocation(filename string, lines []string, linecnt int) string {
 	res := ""
 	for i, line := range lines {
@@ -0,0 +1,51 @@
+package config
+
+import (
+	"time"
+
+	homedir "github.com/mitchellh/go-homedir"
+)
+
+// default amount of time a task status is cached for, based on the status
+const (
+	DefaultCacheTTLOpen        = 15 * time.Minute
+	DefaultCacheTTLClosed      = 72 * time.Hour
+	DefaultCacheTTLNonExistent = 15 * time.Minute
+)
+
+func defaultCacheCfg() *Cache {
+	return &Cache{
+		File: DefaultStatusCache(),
+		TTL: CacheTTL{
+			Open:        DefaultCacheTTLOpen,
+			Closed:      DefaultCacheTTLClosed,
+			NonExistent: DefaultCacheTTLNonExistent,
+		},
+	}
+}
+
+// Cache configuration section for specifying how task statuses are cached in between runs
+type Cache struct {
+	File string   `yaml:"file,omitempty"`
+	TTL  CacheTTL `yaml:"ttl"`
+}
+
+// CacheTTL specifies how long a task status is cached for, based on the status.
+// Tasks, whose status is mapped to other, are cached as long as open ones.
+// Tasks, which were closed for any reason or moved, are cached as long as closed ones.
+// A zero TTL means that tasks with the given status are not cached
+type CacheTTL struct {
+	Open        time.Duration `yaml:"open"`
+	Closed      time.Duration `yaml:"closed"`
+	NonExistent time.Duration `yaml:"nonexistent"`
+}
+
+// DefaultStatusCache for storing fetched task statuses
+func DefaultStatusCache() string {
+	dir, err := homedir.Dir()
+	if err != nil {
+		panic("couldn't read user home directory: " + err.Error())
+	}
+
+	return dir + "/.todocheck/statuscache.yaml"
+}
@@ -5,6 +5,7 @@ import (
 	"os"
 	"regexp"
 	"strings"
+	"time"
 
 	yaml "gopkg.in/yaml.v2"
 )
@@ -12,6 +13,9 @@ import (
 // DefaultLocal contains the default filepath to the local todocheck config for the current repository
 const DefaultLocal = ".todocheck.yaml"
 
+// DefaultRequestTimeout is the default time limit for a single request to the issue tracker
+const DefaultRequestTimeout = 30 * time.Second
+
 var (
 	windowsAbsolutePathPattern = regexp.MustCompile("^[A-Z]{1}:")
 	gitRemoteOriginPattern     = regexp.MustCompile(`(?Um)url\s=\s\w+(://|@)(?P<origin>(?P<host>.+)?(:|/).+)(\.git)?$`)
@@ -19,16 +23,43 @@ var (
 
 // Local todocheck configuration struct definition
 type Local struct {
-	Origin               string       `yaml:"origin"`
-	IssueTracker         IssueTracker `yaml:"issue_tracker"`
-	IgnoredPaths         []string     `yaml:"ignored"`
-	CustomTodos          []string     `yaml:"custom_todos"`
-	Auth                 *Auth        `yaml:"auth"`
-	MatchCaseInsensitive bool         `yaml:"match_case_insensitive"`
+	Origin                   string               `yaml:"origin"`
+	IssueTracker             IssueTracker         `yaml:"issue_tracker"`
+	IgnoredPaths             []string             `yaml:"ignored"`
+	CustomTodos              []string             `yaml:"custom_todos"`
+	Auth                     *Auth                `yaml:"auth"`
+	MatchCaseInsensitive     bool                 `yaml:"match_case_insensitive"`
+	Concurrency              int                  `yaml:"concurrency"`
+	Cache                    *Cache               `yaml:"cache"`
+	Retries                  *Retries             `yaml:"retries"`
+	RequestTimeout           time.Duration        `yaml:"request_timeout"`
+	OnTrackerError           OnTrackerError       `yaml:"on_tracker_error"`
+	StatusMapping            StatusMapping        `yaml:"status_mapping"`
+	OwnerPolicy              OwnerPolicy          `yaml:"owner_policy"`
+	RequiredOpenIssues       RequiredOpenIssues   `yaml:"required_open_issues"`
+	AllowedRepositories      AllowedRepositories  `yaml:"allowed_repositories"`
+	IssueTrackers            []*NamedIssueTracker `yaml:"issue_trackers"`
+	Overrides                Overrides            `yaml:"overrides"`
+	ReportUnusedSuppressions bool                 `yaml:"report_unused_suppressions"`
+	Baseline                 string               `yaml:"baseline"`
+
+	// Basepath of the project, the ignored paths are relative to
+	Basepath string `yaml:"-"`
 }
 
 // NewLocal configuration from a given file path
 func NewLocal(cfgPath, basepath string) (*Local, error) {
+	return newLocal(cfgPath, basepath, false)
+}
+
+// NewOfflineLocal configuration from a given file path, used when the issue tracker is not contacted.
+// Unlike NewLocal, it falls back to the default configuration if there is no configuration file
+// & the issue tracker can't be automatically detected
+func NewOfflineLocal(cfgPath, basepath string) (*Local, error) {
+	return newLocal(cfgPath, basepath, true)
+}
+
+func newLocal(cfgPath, basepath string, isOffline bool) (*Local, error) {
 	if cfgPath == "" {
 		cfgPath = basepath + "/" + DefaultLocal
 	}
@@ -45,12 +76,25 @@ func NewLocal(cfgPath, basepath string) (*Local, error) {
 		}
 	} else {
 		cfg, err = autoDetect(basepath)
-		if err != nil {
+		if err != nil && isOffline {
+			cfg = defaultLocal()
+		} else if err != nil {
 			return nil, fmt.Errorf("file %s not found: unable to automatically detect issue tracker: %w", cfgPath, err)
 		}
 	}
 
+	if cfg.Concurrency == 0 {
+		cfg.Concurrency = cfg.IssueTracker.DefaultConcurrency()
+	}
+
+	cfg.Basepath = basepath
 	cfg.Auth.TokensCache = prependBasepath(cfg.Auth.TokensCache, basepath)
+	cfg.Cache.File = prependBasepath(cfg.Cache.File, basepath)
+	if cfg.Baseline != "" {
+		cfg.Baseline = prependBasepath(cfg.Baseline, basepath)
+	}
+	cfg.setNamedIssueTrackerDefaults(basepath)
+	cfg.setOverrideDefaults(basepath)
 
 	prependDoublestarGlob(cfg.IgnoredPaths, basepath)
 	trimTrailingSlashesFromDirs(cfg.IgnoredPaths)
@@ -68,7 +112,7 @@ func fromFile(cfgPath string) (*Local, error) {
 		return nil, fmt.Errorf("couldn't open local configuration (%s): %w", cfgPath, err)
 	}
 
-	cfg := &Local{Auth: defaultAuthCfg()}
+	cfg := defaultLocal()
 	err = yaml.Unmarshal(bs, &cfg)
 	if err != nil {
 		return nil, fmt.Errorf("failed to unmarshal local configuration (%s): %w", cfgPath, err)
@@ -85,6 +129,9 @@ func autoDetect(basepath string) (*Local, error) {
 
 	result := map[string]string{}
 	match := gitRemoteOriginPattern.FindStringSubmatch(string(bs))
+	if match == nil {
+		return nil, fmt.Errorf("no remote origin found in git config")
+	}
 
 	for i, group := range gitRemoteOriginPattern.SubexpNames() {
 		result[group] = match[i]
@@ -108,11 +155,23 @@ func autoDetect(basepath string) (*Local, error) {
 
 	fmt.Printf("Detected %q as issue tracker since no config file was found.\n", origin)
 
+	cfg := defaultLocal()
+	cfg.IssueTracker = issueTracker
+	cfg.Origin = origin
+
+	return cfg, nil
+}
+
+func defaultLocal() *Local {
 	return &Local{
-		Auth:         defaultAuthCfg(),
-		IssueTracker: issueTracker,
-		Origin:       origin,
-	}, nil
+		Auth:               defaultAuthCfg(),
+		Cache:              defaultCacheCfg(),
+		Retries:            defaultRetriesCfg(),
+		RequestTimeout:     DefaultRequestTimeout,
+		OnTrackerError:     OnTrackerErrorFail,
+		OwnerPolicy:        OwnerPolicyIgnore,
+		RequiredOpenIssues: RequiredOpenIssuesAll,
+	}
 }
 
 func exists(filepath string) bool {
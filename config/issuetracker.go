@@ -49,6 +49,32 @@ var originPatterns = map[IssueTracker]*regexp.Regexp{
 	IssueTrackerAzure:    regexp.MustCompile(`^(https?://)?(www\.)?dev\.azure\.com/([a-zA-Z0-9]+)+\/([a-zA-Z0-9]+)+.*$`),
 }
 
+// issueRefPatterns are the valid formats of issue references for the given issue tracker
+var issueRefPatterns = map[IssueTracker]*regexp.Regexp{
+	IssueTrackerJira:     regexp.MustCompile(`^#?([a-zA-Z][a-zA-Z0-9_]*-[0-9]+|[0-9]+)$`),
+	IssueTrackerGithub:   regexp.MustCompile(`^([\w.\-]+/[\w.\-]+#|#)?[0-9]+$`),
+	IssueTrackerGitlab:   regexp.MustCompile(`^([\w.\-]+(/[\w.\-]+)+#|#)?[0-9]+$`),
+	IssueTrackerPivotal:  regexp.MustCompile(`^#?[0-9]+$`),
+	IssueTrackerRedmine:  regexp.MustCompile(`^#?[0-9]+$`),
+	IssueTrackerYoutrack: regexp.MustCompile(`^#?[a-zA-Z0-9_]+-[0-9]+$`),
+	IssueTrackerAzure:    regexp.MustCompile(`^#?[0-9]+$`),
+}
+
+// defaultConcurrency is the amount of issues fetched concurrently from an issue tracker, unless configured otherwise.
+// Trackers with stricter rate limits are given a lower limit
+var defaultConcurrency = map[IssueTracker]int{
+	IssueTrackerJira:     8,
+	IssueTrackerGithub:   4,
+	IssueTrackerGitlab:   4,
+	IssueTrackerPivotal:  4,
+	IssueTrackerRedmine:  8,
+	IssueTrackerYoutrack: 8,
+	IssueTrackerAzure:    8,
+}
+
+// fallbackConcurrency is used for issue trackers which don't have a default concurrency limit
+const fallbackConcurrency = 4
+
 // IsValid checks if the given issue tracker is among the valid enum values
 func (it IssueTracker) IsValid() bool {
 	for _, other := range validIssueTrackers {
@@ -70,6 +96,17 @@ func (it IssueTracker) IsValidOrigin(origin string) bool {
 	return true
 }
 
+// IsValidIssueRef checks if the given issue reference has a valid format for the given issue tracker.
+// Any issue reference is valid if the issue tracker isn't known
+func (it IssueTracker) IsValidIssueRef(ref string) bool {
+	pattern, ok := issueRefPatterns[it]
+	if !ok {
+		return true
+	}
+
+	return pattern.MatchString(ref)
+}
+
 // IsValidAuthType checks if the given auth type is among the valid auth types for the given issue tracker
 func (it IssueTracker) IsValidAuthType(authType AuthType) bool {
 	for _, validType := range ValidIssueTrackerAuthTypes[it] {
@@ -79,3 +116,12 @@ func (it IssueTracker) IsValidAuthType(authType AuthType) bool {
 	}
 	return false
 }
+
+// DefaultConcurrency returns the default amount of issues to fetch concurrently from the given issue tracker
+func (it IssueTracker) DefaultConcurrency() int {
+	if limit, ok := defaultConcurrency[it]; ok {
+		return limit
+	}
+
+	return fallbackConcurrency
+}
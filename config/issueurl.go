@@ -0,0 +1,136 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+var (
+	// ErrIssueURLMismatch is returned when an issue URL points at a different host or repository than the configured origin
+	ErrIssueURLMismatch = errors.New("issue URL doesn't match the configured origin")
+
+	// ErrInvalidIssueURL is returned when an issue URL doesn't point at an issue of the configured issue tracker
+	ErrInvalidIssueURL = errors.New("invalid issue URL")
+)
+
+// issueURLPatterns match the path of an issue URL for the given issue tracker.
+// The "project" group is the part of the path, which must match the origin's path, if present, & the "id" group is the task ID
+var issueURLPatterns = map[IssueTracker]*regexp.Regexp{
+	IssueTrackerJira:     regexp.MustCompile(`^(?P<project>(?:/[^/]+)*?)/browse/(?P<id>[a-zA-Z][a-zA-Z0-9_]*-[0-9]+)/?$`),
+	IssueTrackerGithub:   regexp.MustCompile(`^(?P<project>/[^/]+/[^/]+)/(?:issues|pull)/(?P<id>[0-9]+)/?$`),
+	IssueTrackerGitlab:   regexp.MustCompile(`^(?P<project>(?:/[^/]+)+?)(?:/-)?/(?:issues|merge_requests|work_items)/(?P<id>[0-9]+)/?$`),
+	IssueTrackerPivotal:  regexp.MustCompile(`^(?:(?P<project>/n/projects/[0-9]+)/stories|/story/show)/(?P<id>[0-9]+)/?$`),
+	IssueTrackerRedmine:  regexp.MustCompile(`^(?P<project>(?:/[^/]+)*?)/issues/(?P<id>[0-9]+)/?$`),
+	IssueTrackerYoutrack: regexp.MustCompile(`^(?P<project>(?:/[^/]+)*?)/issue/(?P<id>[a-zA-Z0-9_]+-[0-9]+)(?:/[^/]*)?$`),
+	IssueTrackerAzure:    regexp.MustCompile(`^(?P<project>/[^/]+/[^/]+)/_workitems/edit/(?P<id>[0-9]+)/?$`),
+}
+
+// issueURLTaskIDPrefix is prepended to the task ID, parsed from an issue URL, for the given issue tracker
+var issueURLTaskIDPrefix = map[IssueTracker]string{
+	IssueTrackerGithub:  "#",
+	IssueTrackerGitlab:  "#",
+	IssueTrackerPivotal: "#",
+	IssueTrackerRedmine: "#",
+}
+
+// IsIssueURL checks if the given issue reference is a URL, rather than a task ID
+func IsIssueURL(ref string) bool {
+	return strings.HasPrefix(ref, "http://") || strings.HasPrefix(ref, "https://")
+}
+
+// TaskIDFor the given issue reference. Issue URLs are parsed into the task ID they point at, other references are returned as is.
+// If the URL points at a different host or repository than the given origin, an ErrIssueURLMismatch is returned
+func (it IssueTracker) TaskIDFor(origin, ref string) (string, error) {
+	if !IsIssueURL(ref) {
+		return ref, nil
+	}
+
+	pattern, ok := issueURLPatterns[it]
+	if !ok {
+		return "", fmt.Errorf("%w: issue URLs are not supported for issue tracker %q", ErrInvalidIssueURL, it)
+	}
+
+	issueURL, err := url.Parse(ref)
+	if err != nil {
+		return "", fmt.Errorf("%w: %s", ErrInvalidIssueURL, err)
+	}
+
+	match := pattern.FindStringSubmatchIndex(issueURL.Path)
+	if match == nil {
+		return "", fmt.Errorf("%w: %s doesn't point at a %s issue", ErrInvalidIssueURL, ref, strings.ToLower(string(it)))
+	}
+
+	originURL, err := url.Parse(withScheme(origin))
+	if err != nil {
+		return "", fmt.Errorf("couldn't parse origin %s: %w", origin, err)
+	}
+
+	if !sameHost(issueURL, originURL) || !sameProject(issueURL.Path, originURL.Path, pattern, match) {
+		return "", fmt.Errorf("%w: %s doesn't point at %s", ErrIssueURLMismatch, ref, origin)
+	}
+
+	id := pattern.SubexpIndex("id")
+	return issueURLTaskIDPrefix[it] + issueURL.Path[match[2*id]:match[2*id+1]], nil
+}
+
+// WebURLFor the page of the given task in the issue tracker's web UI. Tasks in other repositories, e.g. owner/repo#12, are supported for github & gitlab.
+// If the issue tracker's issue URLs are not known, an empty string is returned
+func (it IssueTracker) WebURLFor(origin, taskID string) string {
+	originURL, err := url.Parse(withScheme(origin))
+	if err != nil {
+		return ""
+	}
+
+	project := strings.TrimRight(originURL.Path, "/")
+	repo, taskID := SplitCrossRepoRef(taskID)
+	if repo != "" {
+		project = "/" + repo
+	}
+
+	taskID = strings.TrimPrefix(taskID, "#")
+	base := originURL.Scheme + "://" + originURL.Host
+	switch it {
+	case IssueTrackerJira:
+		return base + project + "/browse/" + taskID
+	case IssueTrackerGithub:
+		return base + project + "/issues/" + taskID
+	case IssueTrackerGitlab:
+		return base + project + "/-/issues/" + taskID
+	case IssueTrackerPivotal:
+		return base + "/story/show/" + taskID
+	case IssueTrackerRedmine:
+		return base + project + "/issues/" + taskID
+	case IssueTrackerYoutrack:
+		return base + project + "/issue/" + taskID
+	case IssueTrackerAzure:
+		return base + project + "/_workitems/edit/" + taskID
+	default:
+		return ""
+	}
+}
+
+func sameHost(issueURL, originURL *url.URL) bool {
+	return strings.EqualFold(strings.TrimPrefix(issueURL.Host, "www."), strings.TrimPrefix(originURL.Host, "www."))
+}
+
+// sameProject checks if the project in the issue URL's path is the one in the origin's path.
+// If the issue URL doesn't specify a project, e.g. for pivotal tracker's /story/show/{id} URLs, only the host is checked
+func sameProject(issuePath, originPath string, pattern *regexp.Regexp, match []int) bool {
+	project := pattern.SubexpIndex("project")
+	if match[2*project] < 0 {
+		return true
+	}
+
+	return strings.EqualFold(issuePath[match[2*project]:match[2*project+1]], strings.TrimRight(originPath, "/"))
+}
+
+func withScheme(origin string) string {
+	if IsIssueURL(origin) {
+		return origin
+	}
+
+	return "https://" + origin
+}
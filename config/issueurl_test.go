@@ -0,0 +1,68 @@
+package config
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestTaskIDFor(t *testing.T) {
+	tests := []struct {
+		issueTracker IssueTracker
+		origin       string
+		ref          string
+		want         string
+		wantErr      error
+	}{
+		{IssueTrackerGithub, "github.com/user/repo", "#12", "#12", nil},
+		{IssueTrackerGithub, "github.com/user/repo", "https://github.com/user/repo/issues/12", "#12", nil},
+		{IssueTrackerGithub, "https://github.com/User/Repo", "https://www.github.com/user/repo/pull/7/", "#7", nil},
+		{IssueTrackerGithub, "github.com/user/repo", "https://github.com/user/other-repo/issues/12", "", ErrIssueURLMismatch},
+		{IssueTrackerGithub, "github.com/user/repo", "https://gitlab.com/user/repo/issues/12", "", ErrIssueURLMismatch},
+		{IssueTrackerGithub, "github.com/user/repo", "https://github.com/user/repo/wiki", "", ErrInvalidIssueURL},
+		{IssueTrackerGitlab, "gitlab.com/group/sub/project", "https://gitlab.com/group/sub/project/-/issues/3", "#3", nil},
+		{IssueTrackerGitlab, "gitlab.com/group/project", "https://gitlab.com/group/project/issues/3", "#3", nil},
+		{IssueTrackerGitlab, "gitlab.com/group/project", "https://gitlab.com/group/other/-/issues/3", "", ErrIssueURLMismatch},
+		{IssueTrackerJira, "https://myorg.atlassian.net", "https://myorg.atlassian.net/browse/ABC-12", "ABC-12", nil},
+		{IssueTrackerJira, "https://jira.example.com:8080/jira", "https://jira.example.com:8080/jira/browse/ABC-12", "ABC-12", nil},
+		{IssueTrackerJira, "https://myorg.atlassian.net", "https://other.atlassian.net/browse/ABC-12", "", ErrIssueURLMismatch},
+		{IssueTrackerPivotal, "pivotaltracker.com/n/projects/123", "https://www.pivotaltracker.com/story/show/456", "#456", nil},
+		{IssueTrackerPivotal, "pivotaltracker.com/n/projects/123", "https://www.pivotaltracker.com/n/projects/321/stories/456", "", ErrIssueURLMismatch},
+		{IssueTrackerRedmine, "https://redmine.example.com", "https://redmine.example.com/issues/42", "#42", nil},
+		{IssueTrackerYoutrack, "https://myorg.youtrack.cloud", "https://myorg.youtrack.cloud/issue/ABC-12/some-title", "ABC-12", nil},
+		{IssueTrackerAzure, "dev.azure.com/org/project", "https://dev.azure.com/org/project/_workitems/edit/42", "42", nil},
+	}
+
+	for _, tt := range tests {
+		got, err := tt.issueTracker.TaskIDFor(tt.origin, tt.ref)
+		if !errors.Is(err, tt.wantErr) {
+			t.Errorf("got error %v for %s, want %v", err, tt.ref, tt.wantErr)
+		}
+		if got != tt.want {
+			t.Errorf("got task ID %q for %s, want %q", got, tt.ref, tt.want)
+		}
+	}
+}
+
+func TestWebURLFor(t *testing.T) {
+	tests := []struct {
+		issueTracker IssueTracker
+		origin       string
+		taskID       string
+		want         string
+	}{
+		{IssueTrackerGithub, "github.com/user/repo", "#12", "https://github.com/user/repo/issues/12"},
+		{IssueTrackerGithub, "https://github.com/user/repo/", "org/lib#3", "https://github.com/org/lib/issues/3"},
+		{IssueTrackerGitlab, "gitlab.com/group/sub/project", "#3", "https://gitlab.com/group/sub/project/-/issues/3"},
+		{IssueTrackerJira, "https://jira.example.com:8080/jira", "ABC-12", "https://jira.example.com:8080/jira/browse/ABC-12"},
+		{IssueTrackerPivotal, "pivotaltracker.com/n/projects/123", "#456", "https://pivotaltracker.com/story/show/456"},
+		{IssueTrackerRedmine, "http://redmine.example.com", "#42", "http://redmine.example.com/issues/42"},
+		{IssueTrackerYoutrack, "https://myorg.youtrack.cloud", "ABC-12", "https://myorg.youtrack.cloud/issue/ABC-12"},
+		{IssueTrackerAzure, "dev.azure.com/org/project", "42", "https://dev.azure.com/org/project/_workitems/edit/42"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.issueTracker.WebURLFor(tt.origin, tt.taskID); got != tt.want {
+			t.Errorf("got URL %q for %s, want %q", got, tt.taskID, tt.want)
+		}
+	}
+}
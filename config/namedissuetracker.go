@@ -0,0 +1,104 @@
+package config
+
+import (
+	"regexp"
+	"strings"
+)
+
+// trackerNamePattern matches the valid names of issue trackers
+const trackerNamePattern = `[a-zA-Z][a-zA-Z0-9_\-]*`
+
+var (
+	validTrackerName = regexp.MustCompile(`^` + trackerNamePattern + `$`)
+
+	// trackerPrefixPattern matches an issue reference, prefixed with the name of the issue tracker it belongs to, e.g. gh:#12.
+	// The names of issue trackers, set by overrides, start with an underscore
+	trackerPrefixPattern = regexp.MustCompile(`^(?P<name>_?` + trackerNamePattern + `):(?P<ref>.+)$`)
+)
+
+// NamedIssueTracker configuration section for an issue tracker, used in addition to the default one.
+// Todos reference its issues via its name as a prefix, e.g. `TODO gh:#12:`, or via issue IDs, which match its id_pattern
+type NamedIssueTracker struct {
+	Name          string        `yaml:"name"`
+	IssueTracker  IssueTracker  `yaml:"issue_tracker"`
+	Origin        string        `yaml:"origin"`
+	Auth          *Auth         `yaml:"auth"`
+	IDPattern     string        `yaml:"id_pattern"`
+	Concurrency   int           `yaml:"concurrency"`
+	StatusMapping StatusMapping `yaml:"status_mapping"`
+
+	// overridePath is the path of the override, which set the issue tracker. It is empty for configured issue trackers
+	overridePath string
+}
+
+// IsValidName checks if the issue tracker's name can be used as a prefix of issue references
+func (t *NamedIssueTracker) IsValidName() bool {
+	return validTrackerName.MatchString(t.Name)
+}
+
+// NamedIssueTracker with the given name or nil if there is none
+func (l *Local) NamedIssueTracker(name string) *NamedIssueTracker {
+	for _, t := range l.IssueTrackers {
+		if strings.EqualFold(t.Name, name) {
+			return t
+		}
+	}
+
+	return nil
+}
+
+// SplitTrackerPrefix splits an issue reference, prefixed with an issue tracker's name, e.g. gh:#12, into the name & the reference, e.g. #12.
+// For references without a prefix, name is empty & the reference is returned as is
+func SplitTrackerPrefix(ref string) (name, unprefixed string) {
+	if IsIssueURL(ref) {
+		return "", ref
+	}
+
+	match := trackerPrefixPattern.FindStringSubmatch(ref)
+	if match == nil {
+		return "", ref
+	}
+
+	return match[trackerPrefixPattern.SubexpIndex("name")], match[trackerPrefixPattern.SubexpIndex("ref")]
+}
+
+// WithTrackerPrefix returns the given issue reference, prefixed with the name of the issue tracker it belongs to.
+// References of the default issue tracker, whose name is empty, are returned as is
+func WithTrackerPrefix(name, ref string) string {
+	if name == "" {
+		return ref
+	}
+
+	return name + ":" + ref
+}
+
+func (l *Local) setNamedIssueTrackerDefaults(basepath string) {
+	for _, t := range l.IssueTrackers {
+		if t.Concurrency == 0 {
+			t.Concurrency = t.IssueTracker.DefaultConcurrency()
+		}
+
+		if t.Auth == nil {
+			t.Auth = &Auth{}
+		}
+
+		l.setAuthDefaults(t.Auth, basepath)
+	}
+}
+
+// setAuthDefaults of an issue tracker's auth section. The tokens cache defaults to the top-level one
+func (l *Local) setAuthDefaults(auth *Auth, basepath string) {
+	if auth.TokensCache == "" {
+		auth.TokensCache = l.Auth.TokensCache
+	} else {
+		auth.TokensCache = prependBasepath(auth.TokensCache, basepath)
+	}
+
+	if auth.Type == "" {
+		auth.Type = AuthTypeNone
+	}
+
+	if auth.Options == nil {
+		auth.Options = map[string]string{}
+	}
+}
@@ -0,0 +1,167 @@
+package config
+
+import (
+	"fmt"
+	"log"
+	"path/filepath"
+	"strings"
+
+	"github.com/bmatcuk/doublestar"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// overrideTrackerPrefix is the prefix of the names of the issue trackers, set by overrides.
+// Unlike the names of configured issue trackers, it doesn't start with a letter, so todos can't reference these trackers via a prefix
+const overrideTrackerPrefix = "_override"
+
+// Override configuration section for the files matching a glob, in the same format as the ignored paths.
+// Options, which are not set, are inherited from the top-level configuration
+type Override struct {
+	Path                 string        `yaml:"-"`
+	IssueTracker         IssueTracker  `yaml:"issue_tracker"`
+	Origin               string        `yaml:"origin"`
+	Auth                 *Auth         `yaml:"auth"`
+	CustomTodos          []string      `yaml:"custom_todos"`
+	MatchCaseInsensitive *bool         `yaml:"match_case_insensitive"`
+	StatusMapping        StatusMapping `yaml:"status_mapping"`
+
+	// trackerName is the name of the issue tracker, which is the default one for the matching files.
+	// It is empty if the override doesn't change the issue tracker
+	trackerName string
+	glob        string
+}
+
+// Overrides keyed by the glob of the files they apply to, in the order they are configured
+type Overrides []*Override
+
+// UnmarshalYAML unmarshals the overrides from a map, keyed by glob, preserving the order of the globs
+func (o *Overrides) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var items yaml.MapSlice
+	if err := unmarshal(&items); err != nil {
+		return err
+	}
+
+	for _, item := range items {
+		glob, ok := item.Key.(string)
+		if !ok {
+			return fmt.Errorf("invalid override path: %v", item.Key)
+		}
+
+		bs, err := yaml.Marshal(item.Value)
+		if err != nil {
+			return fmt.Errorf("couldn't marshal override for %s: %w", glob, err)
+		}
+
+		override := &Override{Path: glob}
+		if err := yaml.Unmarshal(bs, override); err != nil {
+			return fmt.Errorf("invalid override for %s: %w", glob, err)
+		}
+
+		*o = append(*o, override)
+	}
+
+	return nil
+}
+
+// ChangesIssueTracker checks if the override changes the issue tracker, its origin, its auth or its status mapping
+func (o *Override) ChangesIssueTracker() bool {
+	return o.IssueTracker != "" || o.Origin != "" || o.Auth != nil || o.StatusMapping != nil
+}
+
+// IssueTrackerName returns the name of the issue tracker, which is the default one for the files matching the override.
+// It is empty if the override doesn't change the issue tracker
+func (o *Override) IssueTrackerName() string {
+	return o.trackerName
+}
+
+// IsOverride checks if the issue tracker was set by an override, rather than configured under issue_trackers
+func (t *NamedIssueTracker) IsOverride() bool {
+	return t.overridePath != ""
+}
+
+// DisplayName of the issue tracker, used in messages. Issue trackers, set by overrides, are referred to via the override's path
+func (t *NamedIssueTracker) DisplayName() string {
+	if t.IsOverride() {
+		return fmt.Sprintf("override %s", t.overridePath)
+	}
+
+	return t.Name
+}
+
+// For the file at the given path. The first override, whose glob matches the file or any of its parent directories, applies.
+// Nil is returned if none of the overrides matches
+func (o Overrides) For(path string) *Override {
+	for _, override := range o {
+		for dir := path; ; dir = filepath.Dir(dir) {
+			isMatch, err := doublestar.Match(override.glob, dir)
+			if err != nil {
+				log.Fatalf("Couldn't process glob pattern %s for path %s: %s", override.Path, path, err)
+			}
+
+			if isMatch {
+				return override
+			}
+
+			if filepath.Dir(dir) == dir {
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// setOverrideDefaults of the overrides in the given configuration.
+// An override, which changes the issue tracker, adds an issue tracker, which inherits the options it doesn't set from the top-level one
+func (l *Local) setOverrideDefaults(basepath string) {
+	for i, o := range l.Overrides {
+		o.glob = "**/" + strings.TrimPrefix(strings.TrimRight(o.Path, "/"), "./")
+		if o.CustomTodos != nil {
+			o.CustomTodos = addDefaultFormatIfMissing(decodeEscapedReservedCharacters(o.CustomTodos))
+		}
+
+		if !o.ChangesIssueTracker() {
+			continue
+		}
+
+		tracker := &NamedIssueTracker{
+			Name:          fmt.Sprintf("%s%d", overrideTrackerPrefix, i),
+			IssueTracker:  o.IssueTracker,
+			Origin:        o.Origin,
+			Auth:          o.Auth,
+			StatusMapping: o.StatusMapping,
+			overridePath:  o.Path,
+		}
+
+		if tracker.IssueTracker == "" {
+			tracker.IssueTracker = l.IssueTracker
+		}
+
+		if tracker.Origin == "" {
+			tracker.Origin = l.Origin
+		}
+
+		tracker.Concurrency = tracker.IssueTracker.DefaultConcurrency()
+
+		if tracker.Auth == nil {
+			auth := *l.Auth
+			tracker.Auth = &auth
+		} else {
+			l.setAuthDefaults(tracker.Auth, basepath)
+		}
+
+		o.trackerName = tracker.Name
+		l.IssueTrackers = append(l.IssueTrackers, tracker)
+	}
+}
+
+// DisplayTaskID returns the given task ID without the prefix of an issue tracker, set by an override,
+// as todos reference the issues of these trackers without a prefix
+func DisplayTaskID(taskID string) string {
+	name, unprefixed := SplitTrackerPrefix(taskID)
+	if strings.HasPrefix(name, overrideTrackerPrefix) {
+		return unprefixed
+	}
+
+	return taskID
+}
@@ -0,0 +1,92 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const overridesCfg = `
+issue_tracker: GITHUB
+origin: github.com/user/repo
+overrides:
+  services/payments/:
+    issue_tracker: JIRA
+    origin: https://myorg.atlassian.net
+  "**/*.py":
+    custom_todos: ["FIXME"]
+    match_case_insensitive: true
+  ./services:
+    origin: github.com/user/services
+  legacy/:
+    status_mapping:
+      Won't Fix: closed
+`
+
+func TestOverrides(t *testing.T) {
+	dir := t.TempDir()
+	cfgPath := filepath.Join(dir, ".todocheck.yaml")
+	if err := os.WriteFile(cfgPath, []byte(overridesCfg), 0644); err != nil {
+		t.Fatalf("Couldn't write configuration: %v", err)
+	}
+
+	cfg, err := NewOfflineLocal(cfgPath, dir)
+	if err != nil {
+		t.Fatalf("Couldn't read configuration: %v", err)
+	}
+
+	if len(cfg.Overrides) != 4 || len(cfg.IssueTrackers) != 3 {
+		t.Fatalf("Got %d overrides & %d issue trackers, expected 4 & 3", len(cfg.Overrides), len(cfg.IssueTrackers))
+	}
+
+	payments, services := cfg.IssueTrackers[0], cfg.IssueTrackers[1]
+	if payments.IssueTracker != IssueTrackerJira || payments.Origin != "https://myorg.atlassian.net" {
+		t.Errorf("Got issue tracker %s with origin %s for the payments override", payments.IssueTracker, payments.Origin)
+	}
+	if services.IssueTracker != IssueTrackerGithub || services.Origin != "github.com/user/services" {
+		t.Errorf("Got issue tracker %s with origin %s for the services override", services.IssueTracker, services.Origin)
+	}
+	if legacy := cfg.IssueTrackers[2]; legacy.Origin != "github.com/user/repo" || legacy.StatusMapping["Won't Fix"] != MappedStatusClosed {
+		t.Errorf("Got origin %s & status mapping %v for the legacy override", legacy.Origin, legacy.StatusMapping)
+	}
+	if !payments.IsOverride() || payments.DisplayName() != "override services/payments/" {
+		t.Errorf("Expected the payments issue tracker to be set by an override, got display name %q", payments.DisplayName())
+	}
+
+	tests := []struct {
+		path string
+		want *Override
+	}{
+		{"services/payments/main.go", cfg.Overrides[0]},
+		{filepath.Join(dir, "services/payments/api/main.go"), cfg.Overrides[0]},
+		{"services/payments/main.py", cfg.Overrides[0]},
+		{"scripts/main.py", cfg.Overrides[1]},
+		{"services/users/main.go", cfg.Overrides[2]},
+		{"main.go", nil},
+	}
+	for _, tt := range tests {
+		if got := cfg.Overrides.For(tt.path); got != tt.want {
+			t.Errorf("Got override %+v for %s, expected %+v", got, tt.path, tt.want)
+		}
+	}
+
+	if got := cfg.Overrides[1].CustomTodos; len(got) != 2 || got[0] != "FIXME" || got[1] != "TODO" {
+		t.Errorf("Got custom todos %v, expected [FIXME TODO]", got)
+	}
+}
+
+func TestDisplayTaskID(t *testing.T) {
+	tests := []struct {
+		taskID, want string
+	}{
+		{"#12", "#12"},
+		{"gh:#12", "gh:#12"},
+		{"_override0:ABC-12", "ABC-12"},
+		{"_override1:org/lib#3", "org/lib#3"},
+	}
+	for _, tt := range tests {
+		if got := DisplayTaskID(tt.taskID); got != tt.want {
+			t.Errorf("Got %s for %s, expected %s", got, tt.taskID, tt.want)
+		}
+	}
+}
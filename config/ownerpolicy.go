@@ -0,0 +1,30 @@
+package config
+
+// OwnerPolicy specifies how todocheck handles the owners of todos, e.g. alice in `// TODO(alice) #123: fix this`
+type OwnerPolicy string
+
+// possible policies on todo owners
+const (
+	// OwnerPolicyIgnore doesn't check todo owners
+	OwnerPolicyIgnore OwnerPolicy = "ignore"
+
+	// OwnerPolicyAssignee reports todos, whose owner is not among the assignees of their issue
+	OwnerPolicyAssignee OwnerPolicy = "assignee"
+)
+
+// ValidOwnerPolicies is used for validation of the owner_policy option
+var ValidOwnerPolicies = []OwnerPolicy{
+	OwnerPolicyIgnore,
+	OwnerPolicyAssignee,
+}
+
+// IsValid checks if the policy is among the valid enum values
+func (p OwnerPolicy) IsValid() bool {
+	for _, other := range ValidOwnerPolicies {
+		if p == other {
+			return true
+		}
+	}
+
+	return false
+}
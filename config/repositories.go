@@ -0,0 +1,55 @@
+package config
+
+import (
+	"errors"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// ErrRepoNotAllowed is returned when an issue reference points at a repository, which is not among the allowed repositories
+var ErrRepoNotAllowed = errors.New("repository is not in allowed_repositories")
+
+// crossRepoRefPattern matches issue references to another repository, e.g. owner/repo#12 or group/subgroup/project#12
+var crossRepoRefPattern = regexp.MustCompile(`^(?P<repo>[\w.\-]+(?:/[\w.\-]+)+)#(?P<id>[0-9]+)$`)
+
+// AllowedRepositories lists the repositories, whose issues may be referenced from todos, in addition to the origin.
+// Entries are matched case-insensitively & can contain wildcards, e.g. org/*
+type AllowedRepositories []string
+
+// SplitCrossRepoRef splits an issue reference to another repository, e.g. owner/repo#12, into the repository & the issue's task ID, e.g. #12.
+// For references to the origin repository, repo is empty & the task ID is the reference itself
+func SplitCrossRepoRef(ref string) (repo, taskID string) {
+	match := crossRepoRefPattern.FindStringSubmatch(ref)
+	if match == nil {
+		return "", ref
+	}
+
+	return match[crossRepoRefPattern.SubexpIndex("repo")], "#" + match[crossRepoRefPattern.SubexpIndex("id")]
+}
+
+// Allows checks if issues in the given repository may be referenced. If no repositories are listed, all of them are allowed
+func (ar AllowedRepositories) Allows(repo string) bool {
+	if len(ar) == 0 {
+		return true
+	}
+
+	for _, pattern := range ar {
+		if ok, _ := path.Match(strings.ToLower(pattern), strings.ToLower(repo)); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsValid checks if all listed repositories are valid patterns
+func (ar AllowedRepositories) IsValid() bool {
+	for _, pattern := range ar {
+		if _, err := path.Match(pattern, ""); err != nil || pattern == "" {
+			return false
+		}
+	}
+
+	return true
+}
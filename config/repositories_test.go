@@ -0,0 +1,50 @@
+package config
+
+import "testing"
+
+func TestSplitCrossRepoRef(t *testing.T) {
+	tests := []struct {
+		ref        string
+		wantRepo   string
+		wantTaskID string
+	}{
+		{"#12", "", "#12"},
+		{"12", "", "12"},
+		{"ABC-12", "", "ABC-12"},
+		{"org/libB#77", "org/libB", "#77"},
+		{"group/sub.group/project-1#3", "group/sub.group/project-1", "#3"},
+		{"org/libB#abc", "", "org/libB#abc"},
+	}
+
+	for _, tt := range tests {
+		repo, taskID := SplitCrossRepoRef(tt.ref)
+		if repo != tt.wantRepo || taskID != tt.wantTaskID {
+			t.Errorf("got %q, %q for %s, want %q, %q", repo, taskID, tt.ref, tt.wantRepo, tt.wantTaskID)
+		}
+	}
+}
+
+func TestAllowedRepositories(t *testing.T) {
+	tests := []struct {
+		allowed AllowedRepositories
+		repo    string
+		want    bool
+	}{
+		{nil, "org/lib", true},
+		{AllowedRepositories{"org/lib"}, "Org/Lib", true},
+		{AllowedRepositories{"org/lib"}, "org/other", false},
+		{AllowedRepositories{"other/lib", "org/*"}, "org/other", true},
+		{AllowedRepositories{"org/*"}, "org/sub/project", false},
+		{AllowedRepositories{"org/*/*"}, "org/sub/project", true},
+	}
+
+	for _, tt := range tests {
+		if got := tt.allowed.Allows(tt.repo); got != tt.want {
+			t.Errorf("got %v for %s with allowed repositories %v, want %v", got, tt.repo, tt.allowed, tt.want)
+		}
+	}
+
+	if (AllowedRepositories{"org/[lib"}).IsValid() {
+		t.Errorf("Expected malformed pattern to be invalid")
+	}
+}
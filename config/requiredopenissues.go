@@ -0,0 +1,31 @@
+package config
+
+// RequiredOpenIssues specifies which of the issues, referenced by a single todo, have to be open for the todo to be valid,
+// e.g. #12 & #34 in `// TODO #12, #34: remove once both land`
+type RequiredOpenIssues string
+
+// possible policies on todos referencing multiple issues
+const (
+	// RequiredOpenIssuesAll reports each referenced issue, which is not open
+	RequiredOpenIssuesAll RequiredOpenIssues = "all"
+
+	// RequiredOpenIssuesAny reports each referenced issue, which is not open, only if none of them is open
+	RequiredOpenIssuesAny RequiredOpenIssues = "any"
+)
+
+// ValidRequiredOpenIssues is used for validation of the required_open_issues option
+var ValidRequiredOpenIssues = []RequiredOpenIssues{
+	RequiredOpenIssuesAll,
+	RequiredOpenIssuesAny,
+}
+
+// IsValid checks if the policy is among the valid enum values
+func (p RequiredOpenIssues) IsValid() bool {
+	for _, other := range ValidRequiredOpenIssues {
+		if p == other {
+			return true
+		}
+	}
+
+	return false
+}
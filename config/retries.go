@@ -0,0 +1,34 @@
+package config
+
+import "time"
+
+// default retry policy for failed requests to the issue tracker
+const (
+	DefaultMaxRetries = 3
+	DefaultMinBackoff = time.Second
+	DefaultMaxBackoff = 30 * time.Second
+	DefaultMaxWait    = 2 * time.Minute
+)
+
+func defaultRetriesCfg() *Retries {
+	return &Retries{
+		MaxRetries: DefaultMaxRetries,
+		MinBackoff: DefaultMinBackoff,
+		MaxBackoff: DefaultMaxBackoff,
+		MaxWait:    DefaultMaxWait,
+	}
+}
+
+// Retries configuration section for specifying how rate-limited & failed requests to the issue tracker are retried
+type Retries struct {
+	// MaxRetries is the max amount of times a single request is retried
+	MaxRetries int `yaml:"max_retries"`
+
+	// MinBackoff & MaxBackoff bound the exponential backoff in between retries,
+	// when the issue tracker doesn't specify how long to wait
+	MinBackoff time.Duration `yaml:"min_backoff"`
+	MaxBackoff time.Duration `yaml:"max_backoff"`
+
+	// MaxWait is the longest todocheck waits for a rate limit to reset before giving up
+	MaxWait time.Duration `yaml:"max_wait"`
+}
@@ -0,0 +1,87 @@
+package config
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+	"strings"
+)
+
+// MappedStatus is a status, which a raw issue tracker status can be mapped to
+type MappedStatus string
+
+// possible mapped statuses
+const (
+	MappedStatusOpen   MappedStatus = "open"
+	MappedStatusClosed MappedStatus = "closed"
+	MappedStatusOther  MappedStatus = "other"
+)
+
+// ValidMappedStatuses is used for validation of the status mapping
+var ValidMappedStatuses = []MappedStatus{
+	MappedStatusOpen,
+	MappedStatusClosed,
+	MappedStatusOther,
+}
+
+// IsValid checks if the mapped status is among the valid enum values
+func (s MappedStatus) IsValid() bool {
+	for _, other := range ValidMappedStatuses {
+		if s == other {
+			return true
+		}
+	}
+
+	return false
+}
+
+// StatusMapping maps raw issue tracker status names or categories to the status todocheck interprets them as.
+// Statuses which are not mapped are interpreted based on the issue tracker's default mapping
+type StatusMapping map[string]MappedStatus
+
+// Lookup the mapped status for the given raw status. Raw statuses are matched case-insensitively
+func (m StatusMapping) Lookup(rawStatus string) (MappedStatus, bool) {
+	if status, ok := m[rawStatus]; ok {
+		return status, true
+	}
+
+	for raw, status := range m {
+		if strings.EqualFold(raw, rawStatus) {
+			return status, true
+		}
+	}
+
+	return "", false
+}
+
+// StatusMappingFor the given issue tracker. Raw statuses, which the issue tracker doesn't map, fall back to the top-level status mapping
+func (l *Local) StatusMappingFor(t *NamedIssueTracker) StatusMapping {
+	mapping := StatusMapping{}
+	for rawStatus, status := range l.StatusMapping {
+		if _, ok := t.StatusMapping.Lookup(rawStatus); !ok {
+			mapping[rawStatus] = status
+		}
+	}
+
+	for rawStatus, status := range t.StatusMapping {
+		mapping[rawStatus] = status
+	}
+
+	return mapping
+}
+
+// Fingerprint of the status mapping, which changes along with the mapping. It is empty for an empty mapping
+func (m StatusMapping) Fingerprint() string {
+	if len(m) == 0 {
+		return ""
+	}
+
+	var pairs []string
+	for raw, status := range m {
+		pairs = append(pairs, raw+"="+string(status))
+	}
+
+	sort.Strings(pairs)
+	sum := sha256.Sum256([]byte(strings.Join(pairs, "\n")))
+	return hex.EncodeToString(sum[:8])
+}
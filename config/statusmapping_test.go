@@ -0,0 +1,35 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestStatusMappingFor(t *testing.T) {
+	cfg := &Local{StatusMapping: StatusMapping{"Done": MappedStatusClosed, "Blocked": MappedStatusOther}}
+	tests := []struct {
+		mapping, want StatusMapping
+	}{
+		{nil, StatusMapping{"Done": MappedStatusClosed, "Blocked": MappedStatusOther}},
+		{StatusMapping{"done": MappedStatusOpen}, StatusMapping{"done": MappedStatusOpen, "Blocked": MappedStatusOther}},
+		{StatusMapping{"Review": MappedStatusOpen}, StatusMapping{"Done": MappedStatusClosed, "Blocked": MappedStatusOther, "Review": MappedStatusOpen}},
+	}
+	for _, tt := range tests {
+		if got := cfg.StatusMappingFor(&NamedIssueTracker{StatusMapping: tt.mapping}); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Got status mapping %v for %v, expected %v", got, tt.mapping, tt.want)
+		}
+	}
+}
+
+func TestStatusMappingFingerprint(t *testing.T) {
+	mapping := StatusMapping{"Done": MappedStatusClosed, "Blocked": MappedStatusOther}
+	if StatusMapping(nil).Fingerprint() != "" {
+		t.Errorf("Expected the fingerprint of an empty mapping to be empty")
+	}
+	if mapping.Fingerprint() != (StatusMapping{"Blocked": MappedStatusOther, "Done": MappedStatusClosed}).Fingerprint() {
+		t.Errorf("Expected the fingerprints of equal mappings to be equal")
+	}
+	if mapping.Fingerprint() == (StatusMapping{"Done": MappedStatusOpen, "Blocked": MappedStatusOther}).Fingerprint() {
+		t.Errorf("Expected the fingerprints of different mappings to differ")
+	}
+}
@@ -0,0 +1,34 @@
+package config
+
+// OnTrackerError specifies how todocheck handles issues, whose status couldn't be fetched from the issue tracker
+type OnTrackerError string
+
+// possible policies on issue tracker errors
+const (
+	// OnTrackerErrorFail stops todocheck with an error
+	OnTrackerErrorFail OnTrackerError = "fail"
+
+	// OnTrackerErrorWarn reports the todo as a warning, which doesn't fail the check
+	OnTrackerErrorWarn OnTrackerError = "warn"
+
+	// OnTrackerErrorSkip doesn't report the todo
+	OnTrackerErrorSkip OnTrackerError = "skip"
+)
+
+// ValidOnTrackerErrors is used for validation of the on_tracker_error option
+var ValidOnTrackerErrors = []OnTrackerError{
+	OnTrackerErrorFail,
+	OnTrackerErrorWarn,
+	OnTrackerErrorSkip,
+}
+
+// IsValid checks if the policy is among the valid enum values
+func (p OnTrackerError) IsValid() bool {
+	for _, other := range ValidOnTrackerErrors {
+		if p == other {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,168 @@
+// Package diff contains the lines, which were added or modified in a unified diff,
+// so that todocheck can limit its checks to the todos introduced by a change
+package diff
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// hunkHeaderPattern matches the header of a hunk & captures the amount of lines in the old file
+// & the first line & the amount of lines in the new file
+var hunkHeaderPattern = regexp.MustCompile(`^@@ -[0-9]+(?:,(?P<oldCount>[0-9]+))? \+(?P<start>[0-9]+)(?:,(?P<newCount>[0-9]+))? @@`)
+
+// Changes are the added or modified lines per file
+type Changes struct {
+	// lines of each file, keyed by the file's absolute path
+	lines map[string]map[int]bool
+}
+
+// Parse the changes in the given unified diff. The file paths in the diff are relative to dir
+func Parse(r io.Reader, dir string) (*Changes, error) {
+	c := &Changes{lines: map[string]map[int]bool{}}
+	var filename string
+	var linecnt, oldRemaining, newRemaining int
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if oldRemaining > 0 || newRemaining > 0 {
+			switch {
+			case strings.HasPrefix(line, "+"):
+				c.add(filename, linecnt)
+				linecnt++
+				newRemaining--
+			case strings.HasPrefix(line, "-"):
+				oldRemaining--
+			case strings.HasPrefix(line, " ") || line == "":
+				linecnt++
+				oldRemaining--
+				newRemaining--
+			}
+
+			continue
+		}
+
+		if strings.HasPrefix(line, "+++ ") {
+			filename = pathOf(strings.TrimPrefix(line, "+++ "), dir)
+		} else if strings.HasPrefix(line, "@@ ") {
+			match := hunkHeaderPattern.FindStringSubmatch(line)
+			if match == nil {
+				return nil, fmt.Errorf("invalid hunk header: %q", line)
+			}
+
+			linecnt = atoiOr(match[hunkHeaderPattern.SubexpIndex("start")], 0)
+			oldRemaining = atoiOr(match[hunkHeaderPattern.SubexpIndex("oldCount")], 1)
+			newRemaining = atoiOr(match[hunkHeaderPattern.SubexpIndex("newCount")], 1)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("couldn't read diff: %w", err)
+	}
+
+	return c, nil
+}
+
+// FromGit returns the changes in the working tree of the git repository, which contains dir, relative to the given base ref.
+// Changes are computed against the merge base of the ref & HEAD, so that changes, made on the base branch since, are not included
+func FromGit(ctx context.Context, dir, base string) (*Changes, error) {
+	root, err := git(ctx, dir, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return nil, err
+	}
+
+	mergeBase, err := git(ctx, dir, "merge-base", base, "HEAD")
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := git(ctx, dir, "diff", "--unified=0", "--no-color", "--no-ext-diff", "--no-renames", strings.TrimSpace(mergeBase))
+	if err != nil {
+		return nil, err
+	}
+
+	return Parse(strings.NewReader(out), strings.TrimSpace(root))
+}
+
+// HasFile returns true if the given file has any changed lines
+func (c *Changes) HasFile(filename string) bool {
+	return len(c.lines[absPath(filename)]) > 0
+}
+
+// HasLines returns true if any of the lines from first to last in the given file was changed
+func (c *Changes) HasLines(filename string, first, last int) bool {
+	lines := c.lines[absPath(filename)]
+	for linecnt := first; linecnt <= last; linecnt++ {
+		if lines[linecnt] {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (c *Changes) add(filename string, linecnt int) {
+	if filename == "" {
+		return
+	}
+
+	if c.lines[filename] == nil {
+		c.lines[filename] = map[int]bool{}
+	}
+
+	c.lines[filename][linecnt] = true
+}
+
+// atoiOr returns the given number or def if it's empty. Hunk headers omit line counts of 1
+func atoiOr(number string, def int) int {
+	if number == "" {
+		return def
+	}
+
+	n, _ := strconv.Atoi(number)
+	return n
+}
+
+// pathOf the file in the given file header of the diff. Prefixes, such as b/, are stripped
+func pathOf(header, dir string) string {
+	header, _, _ = strings.Cut(header, "\t")
+	if header == "/dev/null" {
+		return ""
+	}
+
+	if unquoted, err := strconv.Unquote(header); err == nil {
+		header = unquoted
+	}
+
+	return absPath(filepath.Join(dir, filepath.FromSlash(strings.TrimPrefix(header, "b/"))))
+}
+
+func absPath(filename string) string {
+	if abs, err := filepath.Abs(filename); err == nil {
+		return abs
+	}
+
+	return filepath.Clean(filename)
+}
+
+func git(ctx context.Context, dir string, args ...string) (string, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "git", append([]string{"-C", dir}, args...)...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("git %s failed: %w: %s", strings.Join(args, " "), err, strings.TrimSpace(stderr.String()))
+	}
+
+	return stdout.String(), nil
+}
@@ -0,0 +1,159 @@
+package diff
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testDiff = `diff --git a/main.go b/main.go
+index 83db48f..bf269f4 100644
+--- a/main.go
++++ b/main.go
+@@ -3,2 +3,3 @@ package main
+ // TODO J1: unchanged
+-// TODO J2: removed
++// TODO J3: modified
++++ this line was added & looks like a file header
+@@ -10 +11,0 @@ func main() {
+-// TODO J4: removed
+diff --git a/old.go b/old.go
+deleted file mode 100644
+--- a/old.go
++++ /dev/null
+@@ -1 +0,0 @@
+-package main
+diff --git a/pkg/new.go b/pkg/new.go
+new file mode 100644
+--- /dev/null
++++ b/pkg/new.go
+@@ -0,0 +1,2 @@
++package pkg
++// TODO J5: added
+`
+
+func TestParse(t *testing.T) {
+	c, err := Parse(strings.NewReader(testDiff), "project")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		filename    string
+		first, last int
+		want        bool
+	}{
+		{"project/main.go", 3, 3, false},
+		{"project/main.go", 4, 4, true},
+		{"project/main.go", 5, 5, true},
+		{"project/main.go", 6, 20, false},
+		{"project/main.go", 1, 4, true},
+		{"project/pkg/new.go", 2, 2, true},
+		{"./project/pkg/../pkg/new.go", 1, 1, true},
+		{"project/old.go", 1, 1, false},
+	}
+	for _, tt := range tests {
+		if got := c.HasLines(tt.filename, tt.first, tt.last); got != tt.want {
+			t.Errorf("Got %v for lines %d-%d of %s, expected %v", got, tt.first, tt.last, tt.filename, tt.want)
+		}
+	}
+
+	if !c.HasFile(filepath.Join("project", "main.go")) || c.HasFile("project/old.go") {
+		t.Errorf("Expected only added & modified files to have changes")
+	}
+}
+
+func TestParseInvalidHunkHeader(t *testing.T) {
+	if _, err := Parse(strings.NewReader("+++ b/main.go\n@@ invalid @@\n"), "."); err == nil {
+		t.Errorf("Expected an error for an invalid hunk header")
+	}
+}
+
+func TestFromGit(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	dir := t.TempDir()
+	run := func(args ...string) {
+		cmd := exec.Command("git", append([]string{"-C", dir, "-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v: %s", args, err, out)
+		}
+	}
+
+	write := func(content string) {
+		if err := os.WriteFile(filepath.Join(dir, "main.go"), []byte(content), 0644); err != nil {
+			t.Fatalf("Couldn't write file: %v", err)
+		}
+	}
+
+	run("init", "-q", "-b", "main")
+	write("package main\n\n// TODO J1: first\n")
+	run("add", "main.go")
+	run("commit", "-q", "-m", "initial")
+	run("checkout", "-q", "-b", "feature")
+	write("package main\n\n// TODO J1: first\n// TODO J2: second\n")
+
+	c, err := FromGit(context.Background(), dir, "main")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	filename := filepath.Join(dir, "main.go")
+	if c.HasLines(filename, 1, 3) || !c.HasLines(filename, 4, 4) {
+		t.Errorf("Expected only line 4 of %s to be changed", filename)
+	}
+}
+
+func TestStagedIndex(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	dir := t.TempDir()
+	run := func(args ...string) {
+		cmd := exec.Command("git", append([]string{"-C", dir, "-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v: %s", args, err, out)
+		}
+	}
+
+	write := func(filename, content string) {
+		if err := os.WriteFile(filepath.Join(dir, filename), []byte(content), 0644); err != nil {
+			t.Fatalf("Couldn't write file: %v", err)
+		}
+	}
+
+	run("init", "-q")
+	write("main.go", "package main\n")
+	write("other.go", "package main\n")
+	run("add", "main.go", "other.go")
+	run("commit", "-q", "-m", "initial")
+
+	write("main.go", "package main\n\n// TODO J1: staged\n")
+	run("add", "main.go")
+	write("main.go", "package main\n\n// TODO J1: staged\n// TODO J2: unstaged\n")
+	write("other.go", "package main\n\n// TODO J3: unstaged\n")
+
+	index, err := StagedIndex(context.Background(), dir)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if !index.HasFile(filepath.Join(dir, "main.go")) || index.HasFile(filepath.Join(dir, "other.go")) {
+		t.Errorf("Expected only main.go to be staged")
+	}
+
+	content, err := index.ReadFile(filepath.Join(dir, "main.go"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if want := "package main\n\n// TODO J1: staged\n"; string(content) != want {
+		t.Errorf("Got staged content %q, expected %q", content, want)
+	}
+}
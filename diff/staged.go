@@ -0,0 +1,59 @@
+package diff
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// Index contains the files, which are staged for the next commit in a git repository.
+// Their content is read from the index, rather than from the working tree, so that unstaged edits are not checked
+type Index struct {
+	root string
+
+	// files, keyed by their absolute path
+	files map[string]bool
+}
+
+// StagedIndex returns the staged files in the git repository, which contains dir. Deleted files are not included
+func StagedIndex(ctx context.Context, dir string) (*Index, error) {
+	root, err := git(ctx, dir, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := git(ctx, dir, "diff", "--cached", "--name-only", "--no-renames", "--diff-filter=ACM", "-z")
+	if err != nil {
+		return nil, err
+	}
+
+	i := &Index{root: absPath(strings.TrimSpace(root)), files: map[string]bool{}}
+	for _, filename := range strings.Split(out, "\x00") {
+		if filename != "" {
+			i.files[absPath(filepath.Join(i.root, filepath.FromSlash(filename)))] = true
+		}
+	}
+
+	return i, nil
+}
+
+// HasFile returns true if the given file is staged
+func (i *Index) HasFile(filename string) bool {
+	return i.files[absPath(filename)]
+}
+
+// ReadFile returns the staged content of the given file
+func (i *Index) ReadFile(filename string) ([]byte, error) {
+	rel, err := filepath.Rel(i.root, absPath(filename))
+	if err != nil {
+		return nil, fmt.Errorf("couldn't find %s in the git repository: %w", filename, err)
+	}
+
+	out, err := git(context.Background(), i.root, "cat-file", "blob", ":"+filepath.ToSlash(rel))
+	if err != nil {
+		return nil, err
+	}
+
+	return []byte(out), nil
+}
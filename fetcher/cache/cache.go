@@ -0,0 +1,283 @@
+// Package cache contains a task status fetcher, which persists fetched task statuses on disk,
+// so that subsequent todocheck runs don't have to contact the issue tracker for recently fetched tasks
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/preslavmihaylov/todocheck/config"
+	"github.com/preslavmihaylov/todocheck/fetcher"
+	"github.com/preslavmihaylov/todocheck/issuetracker/taskstatus"
+	"github.com/preslavmihaylov/todocheck/logger"
+	yaml "gopkg.in/yaml.v2"
+)
+
+var (
+	// DefaultFilePermissions the cache file is created with
+	DefaultFilePermissions = os.FileMode(0600)
+
+	// DefaultDirPermissions the cache file's directory is created with
+	DefaultDirPermissions = os.FileMode(0700)
+)
+
+// Entry of a single cached task status
+type Entry struct {
+	Status       taskstatus.TaskStatus `yaml:"status"`
+	LinkedTaskID string                `yaml:"linked_task_id,omitempty"`
+	Assignees    []string              `yaml:"assignees,omitempty"`
+	Title        string                `yaml:"title,omitempty"`
+	FetchedAt    time.Time             `yaml:"fetched_at"`
+
+	// StatusMapping is the fingerprint of the status mapping, the status was interpreted with
+	StatusMapping string `yaml:"status_mapping,omitempty"`
+}
+
+// store is the on-disk format of the cache. Entries are keyed by issue tracker origin & task ID
+type store struct {
+	Origins map[string]map[string]*Entry `yaml:"origins"`
+}
+
+// Cache of task statuses. On a cache miss, the task status is fetched via the underlying fetcher
+type Cache struct {
+	fetcher       fetcher.StatusFetcher
+	origin        string
+	statusMapping string
+	ttl           config.CacheTTL
+	filename      string
+	refresh       bool
+	now           func() time.Time
+
+	mu      sync.Mutex
+	store   *store
+	isDirty bool
+}
+
+// New task status cache, which is loaded from the given file. The fetcher interprets statuses with the given status mapping,
+// so entries, cached with a different one, are disregarded. If refresh is true, existing cache entries are disregarded,
+// but newly fetched ones are still stored
+func New(f fetcher.StatusFetcher, origin string, statusMapping config.StatusMapping, cfg *config.Cache, refresh bool) (*Cache, error) {
+	s, err := fromFile(cfg.File)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Cache{
+		fetcher:       f,
+		origin:        origin,
+		statusMapping: statusMapping.Fingerprint(),
+		ttl:           cfg.TTL,
+		filename:      cfg.File,
+		refresh:       refresh,
+		now:           time.Now,
+		store:         s,
+	}, nil
+}
+
+// Fetch a task's status from the cache or from the underlying fetcher if there is no valid cache entry
+func (c *Cache) Fetch(ctx context.Context, taskID string) (taskstatus.Result, error) {
+	if entry, ok := c.lookup(taskID); ok {
+		logger.Infof("Using cached status for task %s\n", taskID)
+		return entry.result(), nil
+	}
+
+	status, err := c.fetcher.Fetch(ctx, taskID)
+	if err != nil {
+		return status, err
+	}
+
+	c.put(taskID, status)
+	return status, nil
+}
+
+// BatchSize returns the batch size of the underlying fetcher or zero if it doesn't support batch lookups
+func (c *Cache) BatchSize() int {
+	if bf, ok := c.fetcher.(fetcher.BatchStatusFetcher); ok {
+		return bf.BatchSize()
+	}
+
+	return 0
+}
+
+// FetchBatch fetches the statuses of the given tasks.
+// Only the tasks without a valid cache entry are looked up via the underlying fetcher
+func (c *Cache) FetchBatch(ctx context.Context, taskIDs []string) (map[string]taskstatus.Result, error) {
+	statuses := map[string]taskstatus.Result{}
+	var misses []string
+	for _, taskID := range taskIDs {
+		if entry, ok := c.lookup(taskID); ok {
+			logger.Infof("Using cached status for task %s\n", taskID)
+			statuses[taskID] = entry.result()
+		} else {
+			misses = append(misses, taskID)
+		}
+	}
+
+	if len(misses) == 0 {
+		return statuses, nil
+	}
+
+	bf, ok := c.fetcher.(fetcher.BatchStatusFetcher)
+	if !ok {
+		return nil, errors.New("underlying fetcher doesn't support batch lookups")
+	}
+
+	fetched, err := bf.FetchBatch(ctx, misses)
+	if err != nil {
+		return nil, err
+	}
+
+	for taskID, status := range fetched {
+		c.put(taskID, status)
+		statuses[taskID] = status
+	}
+
+	return statuses, nil
+}
+
+// Save the cache to disk if any entries were added since it was loaded.
+// The entries are merged into the saved ones & the most recently fetched entry of each task is kept
+func (c *Cache) Save() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.isDirty {
+		return nil
+	}
+
+	// the file is reloaded, so that the entries, saved since the cache was loaded, are kept.
+	// E.g. each issue tracker of a repository has a separate cache for the same file, even if they share an origin
+	s, err := fromFile(c.filename)
+	if err != nil {
+		return err
+	}
+
+	for taskID, entry := range c.store.Origins[c.origin] {
+		if s.Origins[c.origin] == nil {
+			s.Origins[c.origin] = map[string]*Entry{}
+		}
+
+		if saved, ok := s.Origins[c.origin][taskID]; !ok || entry.FetchedAt.After(saved.FetchedAt) {
+			s.Origins[c.origin][taskID] = entry
+		}
+	}
+
+	c.store = s
+	c.evictExpired()
+	bs, err := yaml.Marshal(c.store)
+	if err != nil {
+		return fmt.Errorf("failed to marshal status cache: %w", err)
+	}
+
+	dir := filepath.Dir(c.filename)
+	if err := os.MkdirAll(dir, DefaultDirPermissions); err != nil {
+		return fmt.Errorf("couldn't mkdir %s: %w", dir, err)
+	}
+
+	if err := os.WriteFile(c.filename, bs, DefaultFilePermissions); err != nil {
+		return fmt.Errorf("failed to save status cache %s: %w", c.filename, err)
+	}
+
+	c.isDirty = false
+	return nil
+}
+
+func (c *Cache) lookup(taskID string) (*Entry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.refresh {
+		return nil, false
+	}
+
+	entry, ok := c.store.Origins[c.origin][taskID]
+	if !ok || c.isExpired(entry) || entry.StatusMapping != c.statusMapping {
+		return nil, false
+	}
+
+	return entry, true
+}
+
+func (c *Cache) put(taskID string, status taskstatus.Result) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.ttlFor(status.Status) <= 0 {
+		return
+	}
+
+	if c.store.Origins[c.origin] == nil {
+		c.store.Origins[c.origin] = map[string]*Entry{}
+	}
+
+	c.store.Origins[c.origin][taskID] = &Entry{
+		Status:        status.Status,
+		LinkedTaskID:  status.LinkedTaskID,
+		Assignees:     status.Assignees,
+		Title:         status.Title,
+		FetchedAt:     c.now(),
+		StatusMapping: c.statusMapping,
+	}
+	c.isDirty = true
+}
+
+func (c *Cache) evictExpired() {
+	for origin, entries := range c.store.Origins {
+		for taskID, entry := range entries {
+			if c.isExpired(entry) {
+				delete(entries, taskID)
+			}
+		}
+
+		if len(entries) == 0 {
+			delete(c.store.Origins, origin)
+		}
+	}
+}
+
+func (c *Cache) isExpired(entry *Entry) bool {
+	return c.now().After(entry.FetchedAt.Add(c.ttlFor(entry.Status)))
+}
+
+func (c *Cache) ttlFor(status taskstatus.TaskStatus) time.Duration {
+	switch status {
+	case taskstatus.Open, taskstatus.Other:
+		return c.ttl.Open
+	case taskstatus.Closed, taskstatus.ClosedNotPlanned, taskstatus.ClosedDuplicate, taskstatus.Moved:
+		return c.ttl.Closed
+	case taskstatus.NonExistent:
+		return c.ttl.NonExistent
+	default:
+		return 0
+	}
+}
+
+func (e *Entry) result() taskstatus.Result {
+	return taskstatus.Result{Status: e.Status, LinkedTaskID: e.LinkedTaskID, Assignees: e.Assignees, Title: e.Title}
+}
+
+func fromFile(filename string) (*store, error) {
+	s := &store{Origins: map[string]map[string]*Entry{}}
+	bs, err := os.ReadFile(filename)
+	if os.IsNotExist(err) {
+		return s, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("couldn't open status cache %s: %w", filename, err)
+	}
+
+	err = yaml.Unmarshal(bs, s)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal status cache %s: %w", filename, err)
+	}
+
+	if s.Origins == nil {
+		s.Origins = map[string]map[string]*Entry{}
+	}
+
+	return s, nil
+}
@@ -0,0 +1,278 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/preslavmihaylov/todocheck/config"
+	"github.com/preslavmihaylov/todocheck/issuetracker/taskstatus"
+)
+
+const testOrigin = "github.com/user/repo"
+
+func TestCacheStoresFetchedStatuses(t *testing.T) {
+	cfg := testCacheCfg(t)
+	f := &mockFetcher{statuses: map[string]taskstatus.TaskStatus{
+		"1": taskstatus.Open,
+		"2": taskstatus.Closed,
+		"3": taskstatus.NonExistent,
+	}}
+
+	c := mustNewCache(t, f, cfg, false)
+	for taskID, want := range f.statuses {
+		assertStatus(t, c, taskID, want)
+	}
+
+	if err := c.Save(); err != nil {
+		t.Fatalf("Couldn't save cache: %v", err)
+	}
+
+	reloaded := mustNewCache(t, f, cfg, false)
+	for taskID, want := range f.statuses {
+		assertStatus(t, reloaded, taskID, want)
+		if f.calls[taskID] != 1 {
+			t.Errorf("Task %s was fetched %d times, expected 1", taskID, f.calls[taskID])
+		}
+	}
+}
+
+func TestCacheEntriesExpireBasedOnStatus(t *testing.T) {
+	cfg := testCacheCfg(t)
+	f := &mockFetcher{statuses: map[string]taskstatus.TaskStatus{
+		"open":   taskstatus.Open,
+		"closed": taskstatus.Closed,
+	}}
+
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := mustNewCache(t, f, cfg, false)
+	c.now = func() time.Time { return now }
+	assertStatus(t, c, "open", taskstatus.Open)
+	assertStatus(t, c, "closed", taskstatus.Closed)
+
+	now = now.Add(cfg.TTL.Open + time.Second)
+	assertStatus(t, c, "open", taskstatus.Open)
+	assertStatus(t, c, "closed", taskstatus.Closed)
+
+	if f.calls["open"] != 2 {
+		t.Errorf("Expired open task was fetched %d times, expected 2", f.calls["open"])
+	}
+	if f.calls["closed"] != 1 {
+		t.Errorf("Closed task was fetched %d times, expected 1", f.calls["closed"])
+	}
+}
+
+func TestCacheStoresLinkedTasksAndAssignees(t *testing.T) {
+	cfg := testCacheCfg(t)
+	f := &mockFetcher{
+		statuses:    map[string]taskstatus.TaskStatus{"1": taskstatus.ClosedDuplicate},
+		linkedTasks: map[string]string{"1": "2"},
+		assignees:   map[string][]string{"1": {"alice", "bob"}},
+	}
+
+	c := mustNewCache(t, f, cfg, false)
+	assertStatus(t, c, "1", taskstatus.ClosedDuplicate)
+	if err := c.Save(); err != nil {
+		t.Fatalf("Couldn't save cache: %v", err)
+	}
+
+	reloaded := mustNewCache(t, f, cfg, false)
+	status, err := reloaded.Fetch(context.Background(), "1")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if status.Status != taskstatus.ClosedDuplicate || status.LinkedTaskID != "2" {
+		t.Errorf("Cached status is %+v, expected a duplicate of task 2", status)
+	}
+	if !reflect.DeepEqual(status.Assignees, []string{"alice", "bob"}) {
+		t.Errorf("Cached assignees are %v, expected [alice bob]", status.Assignees)
+	}
+	if f.calls["1"] != 1 {
+		t.Errorf("Task was fetched %d times, expected 1", f.calls["1"])
+	}
+}
+
+func TestCacheRefresh(t *testing.T) {
+	cfg := testCacheCfg(t)
+	f := &mockFetcher{statuses: map[string]taskstatus.TaskStatus{"1": taskstatus.Open}}
+
+	c := mustNewCache(t, f, cfg, false)
+	assertStatus(t, c, "1", taskstatus.Open)
+	if err := c.Save(); err != nil {
+		t.Fatalf("Couldn't save cache: %v", err)
+	}
+
+	f.statuses["1"] = taskstatus.Closed
+	refreshed := mustNewCache(t, f, cfg, true)
+	assertStatus(t, refreshed, "1", taskstatus.Closed)
+	if err := refreshed.Save(); err != nil {
+		t.Fatalf("Couldn't save cache: %v", err)
+	}
+
+	reloaded := mustNewCache(t, f, cfg, false)
+	assertStatus(t, reloaded, "1", taskstatus.Closed)
+	if f.calls["1"] != 2 {
+		t.Errorf("Task was fetched %d times, expected 2", f.calls["1"])
+	}
+}
+
+func TestCacheIsKeyedByOrigin(t *testing.T) {
+	cfg := testCacheCfg(t)
+	f := &mockFetcher{statuses: map[string]taskstatus.TaskStatus{"1": taskstatus.Open}}
+
+	c := mustNewCache(t, f, cfg, false)
+	assertStatus(t, c, "1", taskstatus.Open)
+	if err := c.Save(); err != nil {
+		t.Fatalf("Couldn't save cache: %v", err)
+	}
+
+	other, err := New(f, "github.com/user/other", nil, cfg, false)
+	if err != nil {
+		t.Fatalf("Couldn't create cache: %v", err)
+	}
+
+	assertStatus(t, other, "1", taskstatus.Open)
+	if f.calls["1"] != 2 {
+		t.Errorf("Task was fetched %d times, expected 2", f.calls["1"])
+	}
+}
+
+func TestCachesOfDifferentOriginsShareFile(t *testing.T) {
+	cfg := testCacheCfg(t)
+	f := &mockFetcher{statuses: map[string]taskstatus.TaskStatus{"1": taskstatus.Open}}
+
+	c := mustNewCache(t, f, cfg, false)
+	other, err := New(f, "github.com/user/other", nil, cfg, false)
+	if err != nil {
+		t.Fatalf("Couldn't create cache: %v", err)
+	}
+
+	assertStatus(t, c, "1", taskstatus.Open)
+	assertStatus(t, other, "1", taskstatus.Open)
+	for _, cache := range []*Cache{c, other} {
+		if err := cache.Save(); err != nil {
+			t.Fatalf("Couldn't save cache: %v", err)
+		}
+	}
+
+	reloaded := mustNewCache(t, f, cfg, false)
+	assertStatus(t, reloaded, "1", taskstatus.Open)
+	if f.calls["1"] != 2 {
+		t.Errorf("Task was fetched %d times, expected 2", f.calls["1"])
+	}
+}
+
+func TestCachesOfSameOriginShareFile(t *testing.T) {
+	cfg := testCacheCfg(t)
+	f := &mockFetcher{statuses: map[string]taskstatus.TaskStatus{"1": taskstatus.Open, "2": taskstatus.Closed}}
+
+	c := mustNewCache(t, f, cfg, false)
+	other := mustNewCache(t, f, cfg, false)
+	assertStatus(t, c, "1", taskstatus.Open)
+	assertStatus(t, other, "2", taskstatus.Closed)
+	for _, cache := range []*Cache{c, other} {
+		if err := cache.Save(); err != nil {
+			t.Fatalf("Couldn't save cache: %v", err)
+		}
+	}
+
+	reloaded := mustNewCache(t, f, cfg, false)
+	for taskID, want := range f.statuses {
+		assertStatus(t, reloaded, taskID, want)
+		if f.calls[taskID] != 1 {
+			t.Errorf("Task %s was fetched %d times, expected 1", taskID, f.calls[taskID])
+		}
+	}
+}
+
+func TestCacheIsKeyedByStatusMapping(t *testing.T) {
+	cfg := testCacheCfg(t)
+	f := &mockFetcher{statuses: map[string]taskstatus.TaskStatus{"1": taskstatus.Open}}
+
+	c := mustNewCache(t, f, cfg, false)
+	assertStatus(t, c, "1", taskstatus.Open)
+	if err := c.Save(); err != nil {
+		t.Fatalf("Couldn't save cache: %v", err)
+	}
+
+	f.statuses["1"] = taskstatus.Closed
+	mapped, err := New(f, testOrigin, config.StatusMapping{"Done": config.MappedStatusClosed}, cfg, false)
+	if err != nil {
+		t.Fatalf("Couldn't create cache: %v", err)
+	}
+
+	assertStatus(t, mapped, "1", taskstatus.Closed)
+	assertStatus(t, mapped, "1", taskstatus.Closed)
+	if f.calls["1"] != 2 {
+		t.Errorf("Task was fetched %d times, expected 2", f.calls["1"])
+	}
+}
+
+func TestCacheDoesNotStoreErrors(t *testing.T) {
+	cfg := testCacheCfg(t)
+	f := &mockFetcher{statuses: map[string]taskstatus.TaskStatus{}}
+
+	c := mustNewCache(t, f, cfg, false)
+	for i := 0; i < 2; i++ {
+		if _, err := c.Fetch(context.Background(), "FailedFetch"); err == nil {
+			t.Errorf("Expected fetch error, got nil")
+		}
+	}
+
+	if f.calls["FailedFetch"] != 2 {
+		t.Errorf("Task was fetched %d times, expected 2", f.calls["FailedFetch"])
+	}
+}
+
+func testCacheCfg(t *testing.T) *config.Cache {
+	return &config.Cache{
+		File: filepath.Join(t.TempDir(), "statuscache.yaml"),
+		TTL: config.CacheTTL{
+			Open:        time.Minute,
+			Closed:      time.Hour,
+			NonExistent: time.Minute,
+		},
+	}
+}
+
+func mustNewCache(t *testing.T, f *mockFetcher, cfg *config.Cache, refresh bool) *Cache {
+	c, err := New(f, testOrigin, nil, cfg, refresh)
+	if err != nil {
+		t.Fatalf("Couldn't create cache: %v", err)
+	}
+
+	return c
+}
+
+func assertStatus(t *testing.T, c *Cache, taskID string, want taskstatus.TaskStatus) {
+	status, err := c.Fetch(context.Background(), taskID)
+	if err != nil {
+		t.Errorf("Unexpected error for task %s: %v", taskID, err)
+	}
+	if status.Status != want {
+		t.Errorf("Task %s status is %v, expected %v", taskID, status.Status, want)
+	}
+}
+
+type mockFetcher struct {
+	statuses    map[string]taskstatus.TaskStatus
+	linkedTasks map[string]string
+	assignees   map[string][]string
+	calls       map[string]int
+}
+
+func (f *mockFetcher) Fetch(ctx context.Context, taskID string) (taskstatus.Result, error) {
+	if f.calls == nil {
+		f.calls = map[string]int{}
+	}
+
+	f.calls[taskID]++
+	if taskID == "FailedFetch" {
+		return taskstatus.Result{}, errors.New("FailedFetch")
+	}
+
+	return taskstatus.Result{Status: f.statuses[taskID], LinkedTaskID: f.linkedTasks[taskID], Assignees: f.assignees[taskID]}, nil
+}
@@ -1,58 +1,245 @@
 package fetcher
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"io"
 	"net/http"
+	"time"
 
+	"github.com/preslavmihaylov/todocheck/config"
 	"github.com/preslavmihaylov/todocheck/issuetracker"
 	"github.com/preslavmihaylov/todocheck/issuetracker/taskstatus"
 )
 
 // Fetcher for task statuses by contacting task management web apps' rest api
 type Fetcher struct {
-	issueTracker issuetracker.IssueTracker
-	sendRequest  func(req *http.Request) (*http.Response, error)
+	issueTracker  issuetracker.IssueTracker
+	statusMapping config.StatusMapping
+	sendRequest   func(req *http.Request) (*http.Response, error)
+	retries       config.Retries
+	sleep         func(ctx context.Context, d time.Duration) error
+	now           func() time.Time
+	jitter        func(wait time.Duration) time.Duration
 }
 
-// NewFetcher instance
-func NewFetcher(issueTracker issuetracker.IssueTracker) *Fetcher {
-	httpClient := &http.Client{}
-	return &Fetcher{issueTracker, httpClient.Do}
+// NewFetcher instance. Each request attempt is bounded by the configured request timeout, unless it is zero.
+// Failed requests are retried based on the configured retries. If they are not configured, requests are not retried.
+// Fetched task statuses are interpreted based on the given status mapping, falling back to the issue tracker's default one
+func NewFetcher(issueTracker issuetracker.IssueTracker, statusMapping config.StatusMapping, cfg *config.Local) *Fetcher {
+	httpClient := &http.Client{Timeout: cfg.RequestTimeout}
+	f := &Fetcher{
+		issueTracker:  issueTracker,
+		statusMapping: statusMapping,
+		sendRequest:   httpClient.Do,
+		sleep:         sleepContext,
+		now:           time.Now,
+		jitter:        equalJitter,
+	}
+
+	if cfg.Retries != nil {
+		f.retries = *cfg.Retries
+	}
+
+	return f
 }
 
 // Fetch a task's status based on task ID
-func (f *Fetcher) Fetch(taskID string) (taskstatus.TaskStatus, error) {
-	req, err := http.NewRequest("GET", f.issueTracker.IssueURLFor(taskID), nil)
+func (f *Fetcher) Fetch(ctx context.Context, taskID string) (taskstatus.Result, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", f.issueTracker.IssueURLFor(taskID), nil)
 	if err != nil {
-		return taskstatus.None, fmt.Errorf("failed creating new GET request: %w", err)
+		return taskstatus.Result{}, fmt.Errorf("failed creating new GET request: %w", err)
 	}
 
 	err = f.issueTracker.InstrumentMiddleware(req)
 	if err != nil {
-		return taskstatus.None, fmt.Errorf("couldn't instrument authentication middleware: %w", err)
-	}
-
-	resp, err := f.sendRequest(req)
-	if err != nil {
-		return taskstatus.None, fmt.Errorf("couldn't execute GET request: %w", err)
+		return taskstatus.Result{}, fmt.Errorf("couldn't instrument authentication middleware: %w", err)
 	}
-	defer resp.Body.Close()
 
-	body, err := io.ReadAll(resp.Body)
+	resp, body, err := f.sendWithRetries(req)
 	if err != nil {
-		return taskstatus.None, fmt.Errorf("couldn't read response body: %w", err)
+		return taskstatus.Result{}, err
 	} else if resp.StatusCode == http.StatusNotFound {
-		return taskstatus.NonExistent, nil
+		return taskstatus.Result{Status: taskstatus.NonExistent}, nil
 	} else if resp.StatusCode != http.StatusOK {
-		return taskstatus.None, fmt.Errorf("bad status code upon fetching task: %d - %s", resp.StatusCode, string(body))
+		return taskstatus.Result{}, fmt.Errorf("bad status code upon fetching task: %d - %s", resp.StatusCode, string(body))
 	}
 
 	task := f.issueTracker.TaskModel()
 	err = json.Unmarshal(body, &task)
 	if err != nil {
-		return taskstatus.None, fmt.Errorf("couldn't unmarshal response task JSON: %w", err)
+		return taskstatus.Result{}, fmt.Errorf("couldn't unmarshal response task JSON: %w", err)
+	}
+
+	return f.resultOf(ctx, taskID, task)
+}
+
+// BatchSize returns the max amount of tasks FetchBatch can look up at once.
+// Zero means the issue tracker doesn't support batch lookups
+func (f *Fetcher) BatchSize() int {
+	batchTracker, ok := f.issueTracker.(issuetracker.BatchIssueTracker)
+	if !ok {
+		return 0
+	}
+
+	return batchTracker.MaxBatchSize()
+}
+
+// FetchBatch fetches the statuses of the given tasks in a single request.
+// Tasks missing from the batch response are fetched one by one, as batch lookups don't find moved tasks by their old ID.
+// Must only be used when BatchSize is positive
+func (f *Fetcher) FetchBatch(ctx context.Context, taskIDs []string) (map[string]taskstatus.Result, error) {
+	batchTracker, ok := f.issueTracker.(issuetracker.BatchIssueTracker)
+	if !ok {
+		return nil, errors.New("issue tracker doesn't support batch lookups")
+	}
+
+	req, err := batchTracker.BatchRequestFor(taskIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed creating batch request: %w", err)
+	}
+
+	req = req.WithContext(ctx)
+
+	err = f.issueTracker.InstrumentMiddleware(req)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't instrument authentication middleware: %w", err)
+	}
+
+	resp, body, err := f.sendWithRetries(req)
+	if err != nil {
+		return nil, err
+	} else if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("bad status code upon fetching tasks: %d - %s", resp.StatusCode, string(body))
+	}
+
+	tasks, err := batchTracker.TasksFromBatchResponse(taskIDs, body)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't extract tasks from batch response: %w", err)
+	}
+
+	statuses := map[string]taskstatus.Result{}
+	for _, taskID := range taskIDs {
+		task, ok := tasks[taskID]
+		if !ok {
+			statuses[taskID], err = f.Fetch(ctx, taskID)
+			if err != nil {
+				return nil, fmt.Errorf("couldn't fetch task %s, missing from batch response: %w", taskID, err)
+			}
+
+			continue
+		}
+
+		statuses[taskID], err = f.resultOf(ctx, taskID, task)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't get status of task %s: %w", taskID, err)
+		}
+	}
+
+	return statuses, nil
+}
+
+// resultOf looking up the given task, based on its status & the task it links to, if any.
+// If the issue tracker reports that the task was moved, the result is the task's new ID instead
+func (f *Fetcher) resultOf(ctx context.Context, taskID string, task issuetracker.Task) (taskstatus.Result, error) {
+	if movedTracker, ok := f.issueTracker.(issuetracker.MovedIssueTracker); ok {
+		if newTaskID := movedTracker.MovedTaskID(taskID, task); newTaskID != "" {
+			return taskstatus.Result{Status: taskstatus.Moved, LinkedTaskID: newTaskID}, nil
+		}
+	}
+
+	status, err := f.statusOf(task)
+	if err != nil {
+		return taskstatus.Result{}, err
+	}
+
+	res := taskstatus.Result{Status: status}
+	if linkedTask, ok := task.(issuetracker.LinkedTask); ok {
+		res.LinkedTaskID = linkedTask.LinkedTaskID()
+	}
+
+	if res.LinkedTaskID == "" {
+		res.LinkedTaskID, err = f.lookupLinkedTaskID(ctx, task)
+		if err != nil {
+			return taskstatus.Result{}, err
+		}
+	}
+
+	if assignedTask, ok := task.(issuetracker.AssignedTask); ok {
+		res.Assignees = assignedTask.Assignees()
+	}
+
+	if titledTask, ok := task.(issuetracker.TitledTask); ok {
+		res.Title = titledTask.Title()
+	}
+
+	return res, nil
+}
+
+// lookupLinkedTaskID of the task, the given task links to, if the issue tracker has to look it up.
+// If the linked task can't be found, e.g. as it was moved to a project the user can't access, an empty string is returned
+func (f *Fetcher) lookupLinkedTaskID(ctx context.Context, task issuetracker.Task) (string, error) {
+	lookupTracker, ok := f.issueTracker.(issuetracker.LinkLookupIssueTracker)
+	if !ok {
+		return "", nil
+	}
+
+	req, err := lookupTracker.LinkedTaskRequestFor(task)
+	if err != nil {
+		return "", fmt.Errorf("failed creating linked task request: %w", err)
+	} else if req == nil {
+		return "", nil
+	}
+
+	req = req.WithContext(ctx)
+	err = f.issueTracker.InstrumentMiddleware(req)
+	if err != nil {
+		return "", fmt.Errorf("couldn't instrument authentication middleware: %w", err)
+	}
+
+	resp, body, err := f.sendWithRetries(req)
+	if err != nil {
+		return "", err
+	} else if resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusForbidden {
+		return "", nil
+	} else if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("bad status code upon fetching linked task: %d - %s", resp.StatusCode, string(body))
+	}
+
+	linkedTaskID, err := lookupTracker.LinkedTaskIDFrom(body)
+	if err != nil {
+		return "", fmt.Errorf("couldn't extract linked task: %w", err)
+	}
+
+	return linkedTaskID, nil
+}
+
+// statusOf the given task. The first of its raw statuses, which is present in the status mapping, determines the status.
+// If none of them is mapped, the issue tracker's default mapping is used.
+// Statuses mapped to closed keep the reason the task was closed for, if the issue tracker's default mapping knows it,
+// including that it was moved
+func (f *Fetcher) statusOf(task issuetracker.Task) (taskstatus.TaskStatus, error) {
+	for _, rawStatus := range task.RawStatuses() {
+		mapped, ok := f.statusMapping.Lookup(rawStatus)
+		if !ok {
+			continue
+		}
+
+		switch mapped {
+		case config.MappedStatusOpen:
+			return taskstatus.Open, nil
+		case config.MappedStatusClosed:
+			if status, err := task.GetStatus(); err == nil && (status.IsClosed() || status == taskstatus.Moved) {
+				return status, nil
+			}
+
+			return taskstatus.Closed, nil
+		case config.MappedStatusOther:
+			return taskstatus.Other, nil
+		default:
+			return taskstatus.None, fmt.Errorf("unknown mapped status %s for raw status %s", mapped, rawStatus)
+		}
 	}
 
 	return task.GetStatus()
@@ -2,20 +2,26 @@ package fetcher
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"io"
 	"net/http"
+	"reflect"
+	"strings"
 	"testing"
 
+	checkererrors "github.com/preslavmihaylov/todocheck/checker/errors"
+	"github.com/preslavmihaylov/todocheck/config"
 	"github.com/preslavmihaylov/todocheck/issuetracker"
+	"github.com/preslavmihaylov/todocheck/issuetracker/factory"
 	"github.com/preslavmihaylov/todocheck/issuetracker/taskstatus"
 )
 
 var errTest = errors.New("")
 
 func TestFetch(t *testing.T) {
-	fetcher := NewFetcher(mockIssueTracker{})
+	fetcher := NewFetcher(mockIssueTracker{}, nil, &config.Local{})
 	testJSON, err := json.Marshal(mockTask{})
 	if err != nil {
 		t.Fatalf("Test json is bad")
@@ -79,9 +85,9 @@ func TestFetch(t *testing.T) {
 	for _, tt := range testData {
 		t.Run(tt.Task, func(t *testing.T) {
 			fetcher.sendRequest = tt.Client.sendRequest
-			taskStatus, err := fetcher.Fetch(tt.Task)
-			if taskStatus != taskstatus.TaskStatus(tt.Status) {
-				t.Errorf("Task status is %v, expected %v", taskStatus, taskstatus.TaskStatus(tt.Status))
+			taskStatus, err := fetcher.Fetch(context.Background(), tt.Task)
+			if taskStatus.Status != taskstatus.TaskStatus(tt.Status) {
+				t.Errorf("Task status is %v, expected %v", taskStatus.Status, taskstatus.TaskStatus(tt.Status))
 			}
 			if (err == nil) != (tt.Err == nil) { // Doesn't care about error message or type
 				t.Errorf("Fetch error is %v, expected %v", err, tt.Err)
@@ -93,13 +99,22 @@ func TestFetch(t *testing.T) {
 
 // Mocking Task
 type mockTask struct {
-	Status string
+	Status     string
+	AssignedTo []string
 }
 
 func (t mockTask) GetStatus() (taskstatus.TaskStatus, error) {
 	return taskstatus.Open, nil
 }
 
+func (t mockTask) RawStatuses() []string {
+	return []string{t.Status, "Category"}
+}
+
+func (t mockTask) Assignees() []string {
+	return t.AssignedTo
+}
+
 // Mocking IssueTracker
 type mockIssueTracker struct {
 }
@@ -153,3 +168,256 @@ func (r errReader) Read(body []byte) (int, error) {
 func (r errReader) Close() error {
 	return nil
 }
+
+func TestFetchBatch(t *testing.T) {
+	fetcher := NewFetcher(mockBatchIssueTracker{}, nil, &config.Local{})
+	if fetcher.BatchSize() != 2 {
+		t.Errorf("Batch size is %d, expected 2", fetcher.BatchSize())
+	}
+
+	var singleFetches []string
+	fetcher.sendRequest = func(req *http.Request) (*http.Response, error) {
+		if req.URL.Path == "batch" {
+			return mockClient{StatusCode: 200, Body: io.NopCloser(bytes.NewReader([]byte("{}"))), Err: nil}.sendRequest(req)
+		}
+
+		singleFetches = append(singleFetches, req.URL.Path)
+		return mockClient{StatusCode: 404, Body: io.NopCloser(bytes.NewReader([]byte("{}"))), Err: nil}.sendRequest(req)
+	}
+
+	statuses, err := fetcher.FetchBatch(context.Background(), []string{"Found", "Missing"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if statuses["Found"].Status != taskstatus.Open {
+		t.Errorf("Task status is %v, expected %v", statuses["Found"].Status, taskstatus.Open)
+	}
+	if statuses["Missing"].Status != taskstatus.NonExistent {
+		t.Errorf("Task status is %v, expected %v", statuses["Missing"].Status, taskstatus.NonExistent)
+	}
+	if len(singleFetches) != 1 || singleFetches[0] != "Missing" {
+		t.Errorf("Tasks fetched one by one are %v, expected only the one missing from the batch response", singleFetches)
+	}
+
+	fetcher.sendRequest = mockClient{StatusCode: 400, Body: io.NopCloser(bytes.NewReader([]byte("{}"))), Err: nil}.sendRequest
+	if _, err := fetcher.FetchBatch(context.Background(), []string{"Found"}); err == nil {
+		t.Errorf("Expected error on bad status code, got nil")
+	}
+
+	if NewFetcher(mockIssueTracker{}, nil, &config.Local{}).BatchSize() != 0 {
+		t.Errorf("Expected batch size of issue tracker without batch support to be 0")
+	}
+}
+
+func TestFetchWithStatusMapping(t *testing.T) {
+	testData := []struct {
+		Name      string
+		RawStatus string
+		Mapping   config.StatusMapping
+		Status    taskstatus.TaskStatus
+	}{
+		{"NoMapping", "Won't Do", nil, taskstatus.Open},
+		{"UnmappedStatus", "In Progress", config.StatusMapping{"Won't Do": config.MappedStatusClosed}, taskstatus.Open},
+		{"MappedStatus", "Won't Do", config.StatusMapping{"Won't Do": config.MappedStatusClosed}, taskstatus.Closed},
+		{"CaseInsensitiveMapping", "Won't Do", config.StatusMapping{"won't do": config.MappedStatusOther}, taskstatus.Other},
+		{"MappedCategory", "Won't Do", config.StatusMapping{"category": config.MappedStatusClosed}, taskstatus.Closed},
+		{"StatusTakesPrecedenceOverCategory", "Won't Do", config.StatusMapping{
+			"Category": config.MappedStatusClosed,
+			"Won't Do": config.MappedStatusOpen,
+		}, taskstatus.Open},
+	}
+	for _, tt := range testData {
+		t.Run(tt.Name, func(t *testing.T) {
+			testJSON, err := json.Marshal(mockTask{Status: tt.RawStatus})
+			if err != nil {
+				t.Fatalf("Test json is bad")
+			}
+
+			fetcher := NewFetcher(mockIssueTracker{}, tt.Mapping, &config.Local{})
+			fetcher.sendRequest = mockClient{StatusCode: 200, Body: io.NopCloser(bytes.NewReader(testJSON)), Err: nil}.sendRequest
+			taskStatus, err := fetcher.Fetch(context.Background(), "GoodFetch")
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if taskStatus.Status != tt.Status {
+				t.Errorf("Task status is %v, expected %v", taskStatus.Status, tt.Status)
+			}
+		})
+	}
+}
+
+func TestFetchMovedTask(t *testing.T) {
+	testJSON, err := json.Marshal(mockTask{})
+	if err != nil {
+		t.Fatalf("Test json is bad")
+	}
+
+	fetcher := NewFetcher(mockMovedIssueTracker{}, nil, &config.Local{})
+	for taskID, want := range map[string]taskstatus.Result{
+		"MovedTask": {Status: taskstatus.Moved, LinkedTaskID: "NewTask"},
+		"GoodFetch": {Status: taskstatus.Open},
+	} {
+		fetcher.sendRequest = mockClient{StatusCode: 200, Body: io.NopCloser(bytes.NewReader(testJSON)), Err: nil}.sendRequest
+		res, err := fetcher.Fetch(context.Background(), taskID)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if !reflect.DeepEqual(res, want) {
+			t.Errorf("Task %s result is %+v, expected %+v", taskID, res, want)
+		}
+	}
+}
+
+func TestFetchTaskWithLinkLookup(t *testing.T) {
+	testJSON, err := json.Marshal(mockTask{})
+	if err != nil {
+		t.Fatalf("Test json is bad")
+	}
+
+	for _, tt := range []struct {
+		LookupStatusCode int
+		Want             string
+		Err              bool
+	}{
+		{LookupStatusCode: 200, Want: "NewTask"},
+		{LookupStatusCode: 404, Want: ""},
+		{LookupStatusCode: 500, Err: true},
+	} {
+		fetcher := NewFetcher(mockLinkLookupIssueTracker{}, nil, &config.Local{})
+		fetcher.sendRequest = func(req *http.Request) (*http.Response, error) {
+			if req.URL.Path == "lookup" {
+				return &http.Response{StatusCode: tt.LookupStatusCode, Body: io.NopCloser(bytes.NewReader([]byte("NewTask")))}, nil
+			}
+
+			return &http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewReader(testJSON))}, nil
+		}
+
+		res, err := fetcher.Fetch(context.Background(), "MovedTask")
+		if (err != nil) != tt.Err {
+			t.Fatalf("Fetch error is %v, expected error: %v", err, tt.Err)
+		}
+		if res.LinkedTaskID != tt.Want {
+			t.Errorf("Linked task upon lookup status %d is %q, expected %q", tt.LookupStatusCode, res.LinkedTaskID, tt.Want)
+		}
+	}
+}
+
+func TestFetchGitlabMovedTaskWithInaccessibleNotes(t *testing.T) {
+	gitlabTracker, err := factory.NewIssueTrackerFrom(context.Background(), config.IssueTrackerGitlab, nil, "gitlab.com/user/project")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	for _, statusCode := range []int{http.StatusForbidden, http.StatusNotFound} {
+		fetcher := NewFetcher(gitlabTracker, nil, &config.Local{})
+		fetcher.sendRequest = func(req *http.Request) (*http.Response, error) {
+			if strings.HasSuffix(req.URL.Path, "/notes") {
+				return &http.Response{StatusCode: statusCode, Body: io.NopCloser(strings.NewReader(`{"message": "forbidden"}`))}, nil
+			}
+
+			body := `{"iid": 7, "project_id": 42, "state": "closed", "moved_to_id": 1234}`
+			return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(body))}, nil
+		}
+
+		res, err := fetcher.Fetch(context.Background(), "#7")
+		if err != nil {
+			t.Fatalf("Unexpected error upon notes lookup status %d: %v", statusCode, err)
+		}
+		if res.Status != taskstatus.Moved || res.LinkedTaskID != "" {
+			t.Errorf("Task result upon notes lookup status %d is %+v, expected a moved task without a new ID", statusCode, res)
+		}
+
+		todoErr := checkererrors.IssueMovedErr("main.go", []string{"// TODO #7"}, 1, "#7", res.LinkedTaskID)
+		if !strings.Contains(todoErr.Error(), "moved to another project") {
+			t.Errorf("Todo error upon notes lookup status %d is %q, expected it to say the issue was moved to another project", statusCode, todoErr)
+		}
+	}
+}
+
+func TestFetchGitlabMovedTaskMappedToClosed(t *testing.T) {
+	gitlabTracker, err := factory.NewIssueTrackerFrom(context.Background(), config.IssueTrackerGitlab, nil, "gitlab.com/user/project")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	fetcher := NewFetcher(gitlabTracker, config.StatusMapping{"closed": config.MappedStatusClosed}, &config.Local{})
+	fetcher.sendRequest = func(req *http.Request) (*http.Response, error) {
+		body := `{"iid": 7, "project_id": 42, "state": "closed", "moved_to_id": 1234}`
+		if strings.HasSuffix(req.URL.Path, "/notes") {
+			body = `[{"body": "moved to group/lib#12", "system": true}]`
+		}
+
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(body))}, nil
+	}
+
+	res, err := fetcher.Fetch(context.Background(), "#7")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	want := taskstatus.Result{Status: taskstatus.Moved, LinkedTaskID: "group/lib#12"}
+	if !reflect.DeepEqual(res, want) {
+		t.Errorf("Task result is %+v, expected %+v", res, want)
+	}
+}
+
+func TestFetchAssignedTask(t *testing.T) {
+	testJSON, err := json.Marshal(mockTask{AssignedTo: []string{"alice", "bob"}})
+	if err != nil {
+		t.Fatalf("Test json is bad")
+	}
+
+	fetcher := NewFetcher(mockIssueTracker{}, nil, &config.Local{})
+	fetcher.sendRequest = mockClient{StatusCode: 200, Body: io.NopCloser(bytes.NewReader(testJSON)), Err: nil}.sendRequest
+	res, err := fetcher.Fetch(context.Background(), "GoodFetch")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(res.Assignees, []string{"alice", "bob"}) {
+		t.Errorf("Task assignees are %v, expected [alice bob]", res.Assignees)
+	}
+}
+
+// Mocking MovedIssueTracker
+type mockMovedIssueTracker struct {
+	mockIssueTracker
+}
+
+func (it mockMovedIssueTracker) MovedTaskID(taskID string, task issuetracker.Task) string {
+	if taskID == "MovedTask" {
+		return "NewTask"
+	}
+
+	return ""
+}
+
+// Mocking LinkLookupIssueTracker
+type mockLinkLookupIssueTracker struct {
+	mockIssueTracker
+}
+
+func (it mockLinkLookupIssueTracker) LinkedTaskRequestFor(task issuetracker.Task) (*http.Request, error) {
+	return http.NewRequest("GET", "lookup", nil)
+}
+
+func (it mockLinkLookupIssueTracker) LinkedTaskIDFrom(body []byte) (string, error) {
+	return string(body), nil
+}
+
+// Mocking BatchIssueTracker
+type mockBatchIssueTracker struct {
+	mockIssueTracker
+}
+
+func (it mockBatchIssueTracker) MaxBatchSize() int {
+	return 2
+}
+
+func (it mockBatchIssueTracker) BatchRequestFor(taskIDs []string) (*http.Request, error) {
+	return http.NewRequest("GET", "batch", nil)
+}
+
+func (it mockBatchIssueTracker) TasksFromBatchResponse(taskIDs []string, body []byte) (map[string]issuetracker.Task, error) {
+	return map[string]issuetracker.Task{"Found": &mockTask{}}, nil
+}
@@ -0,0 +1,67 @@
+package fetcher
+
+import (
+	"context"
+	"errors"
+
+	"github.com/preslavmihaylov/todocheck/issuetracker/taskstatus"
+)
+
+// Limiter of the amount of concurrent lookups via the underlying fetcher, e.g. the lookups of a single issue tracker.
+// Lookups beyond the limit wait for one of the running ones to finish
+type Limiter struct {
+	fetcher StatusFetcher
+	slots   chan struct{}
+}
+
+// NewLimiter instance, which runs at most concurrency lookups at a time
+func NewLimiter(f StatusFetcher, concurrency int) *Limiter {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	return &Limiter{fetcher: f, slots: make(chan struct{}, concurrency)}
+}
+
+// Fetch a task's status via the underlying fetcher, once a slot is free
+func (l *Limiter) Fetch(ctx context.Context, taskID string) (taskstatus.Result, error) {
+	if err := l.acquire(ctx); err != nil {
+		return taskstatus.Result{}, err
+	}
+	defer l.release()
+
+	return l.fetcher.Fetch(ctx, taskID)
+}
+
+// BatchSize returns the batch size of the underlying fetcher or zero if it doesn't support batch lookups
+func (l *Limiter) BatchSize() int {
+	return batchSizeOf(l.fetcher)
+}
+
+// FetchBatch fetches the statuses of the given tasks via the underlying fetcher, once a slot is free
+func (l *Limiter) FetchBatch(ctx context.Context, taskIDs []string) (map[string]taskstatus.Result, error) {
+	bf, ok := l.fetcher.(BatchStatusFetcher)
+	if !ok {
+		return nil, errors.New("underlying fetcher doesn't support batch lookups")
+	}
+
+	if err := l.acquire(ctx); err != nil {
+		return nil, err
+	}
+	defer l.release()
+
+	return bf.FetchBatch(ctx, taskIDs)
+}
+
+func (l *Limiter) acquire(ctx context.Context) error {
+	select {
+	case l.slots <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (l *Limiter) release() {
+	<-l.slots
+}
@@ -0,0 +1,32 @@
+package fetcher
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestLimiterWaitsForFreeSlot(t *testing.T) {
+	f := &countingFetcher{calls: map[string]int{}}
+	l := NewLimiter(f, 1)
+	l.slots <- struct{}{}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if _, err := l.Fetch(ctx, "1"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Got error %v, expected %v", err, context.DeadlineExceeded)
+	}
+	if f.calls["1"] != 0 {
+		t.Errorf("Task was fetched %d times, expected 0", f.calls["1"])
+	}
+
+	<-l.slots
+	if _, err := l.Fetch(context.Background(), "1"); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if f.calls["1"] != 1 {
+		t.Errorf("Task was fetched %d times, expected 1", f.calls["1"])
+	}
+}
@@ -0,0 +1,200 @@
+package fetcher
+
+import (
+	"context"
+	"sync"
+
+	"github.com/preslavmihaylov/todocheck/issuetracker/taskstatus"
+	"github.com/preslavmihaylov/todocheck/logger"
+)
+
+// StatusFetcher fetches the status of a single task
+type StatusFetcher interface {
+	Fetch(ctx context.Context, taskID string) (taskstatus.Result, error)
+}
+
+// Pool resolves task statuses through a bounded number of concurrent workers.
+// Results are memoized, so each distinct task is fetched at most once
+type Pool struct {
+	fetcher     StatusFetcher
+	concurrency int
+
+	mu      sync.Mutex
+	results map[string]*fetchResult
+}
+
+// BatchStatusFetcher is a status fetcher, which can also fetch the statuses of multiple tasks at once
+type BatchStatusFetcher interface {
+	StatusFetcher
+
+	// BatchSize returns the max amount of tasks FetchBatch can look up at once. Zero means batching is not available
+	BatchSize() int
+
+	// FetchBatch fetches the statuses of the given tasks, keyed by task ID
+	FetchBatch(ctx context.Context, taskIDs []string) (map[string]taskstatus.Result, error)
+}
+
+type job struct {
+	taskID string
+	res    *fetchResult
+}
+
+type fetchResult struct {
+	done   chan struct{}
+	status taskstatus.Result
+	err    error
+}
+
+// NewPool instance, which fetches at most concurrency tasks at a time
+func NewPool(f StatusFetcher, concurrency int) *Pool {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	return &Pool{
+		fetcher:     f,
+		concurrency: concurrency,
+		results:     map[string]*fetchResult{},
+	}
+}
+
+// Prefetch the statuses of the given tasks concurrently.
+// If the underlying fetcher supports it, tasks are looked up in batches.
+// Duplicate task IDs & tasks which were already fetched are skipped.
+// Fetch errors are not returned here, they are returned by Fetch for the given task.
+// If the context is done, the pending tasks are resolved with the context's error
+func (p *Pool) Prefetch(ctx context.Context, taskIDs []string) {
+	var pending []*job
+	for _, taskID := range taskIDs {
+		if res, isOwner := p.resultFor(taskID); isOwner {
+			pending = append(pending, &job{taskID, res})
+		}
+	}
+
+	batches := p.splitInBatches(pending)
+	queue := make(chan []*job)
+	var wg sync.WaitGroup
+	for i := 0; i < p.concurrency && i < len(batches); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for batch := range queue {
+				p.resolveBatch(ctx, batch)
+			}
+		}()
+	}
+
+	for _, batch := range batches {
+		queue <- batch
+	}
+
+	close(queue)
+	wg.Wait()
+}
+
+// Fetch a task's status, reusing the result of a previous fetch for the same task if available
+func (p *Pool) Fetch(ctx context.Context, taskID string) (taskstatus.Result, error) {
+	res, isOwner := p.resultFor(taskID)
+	if isOwner {
+		p.resolve(ctx, taskID, res)
+	}
+
+	select {
+	case <-res.done:
+		return res.status, res.err
+	default:
+	}
+
+	select {
+	case <-res.done:
+		return res.status, res.err
+	case <-ctx.Done():
+		return taskstatus.Result{}, ctx.Err()
+	}
+}
+
+// Reset the memoized results, so that each task is fetched again on its next lookup
+func (p *Pool) Reset() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.results = map[string]*fetchResult{}
+}
+
+// resultFor the given task. isOwner is true if the caller is responsible for resolving the result
+func (p *Pool) resultFor(taskID string) (res *fetchResult, isOwner bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if res, ok := p.results[taskID]; ok {
+		return res, false
+	}
+
+	res = &fetchResult{done: make(chan struct{})}
+	p.results[taskID] = res
+	return res, true
+}
+
+func (p *Pool) resolve(ctx context.Context, taskID string, res *fetchResult) {
+	if err := ctx.Err(); err != nil {
+		res.err = err
+	} else {
+		res.status, res.err = p.fetcher.Fetch(ctx, taskID)
+	}
+
+	close(res.done)
+}
+
+func (p *Pool) splitInBatches(jobs []*job) [][]*job {
+	batchSize := 1
+	if bf, ok := p.fetcher.(BatchStatusFetcher); ok && bf.BatchSize() > 0 {
+		batchSize = bf.BatchSize()
+	}
+
+	var batches [][]*job
+	for len(jobs) > batchSize {
+		batches = append(batches, jobs[:batchSize])
+		jobs = jobs[batchSize:]
+	}
+
+	if len(jobs) > 0 {
+		batches = append(batches, jobs)
+	}
+
+	return batches
+}
+
+// resolveBatch looks up all jobs in a single batch. If that fails, jobs are resolved one by one
+func (p *Pool) resolveBatch(ctx context.Context, batch []*job) {
+	bf, ok := p.fetcher.(BatchStatusFetcher)
+	if !ok || len(batch) == 1 || ctx.Err() != nil {
+		for _, j := range batch {
+			p.resolve(ctx, j.taskID, j.res)
+		}
+
+		return
+	}
+
+	taskIDs := make([]string, len(batch))
+	for i, j := range batch {
+		taskIDs[i] = j.taskID
+	}
+
+	statuses, err := bf.FetchBatch(ctx, taskIDs)
+	if err != nil {
+		if ctx.Err() == nil {
+			logger.Infof("Batch lookup of %d tasks failed, falling back to fetching them one by one: %s\n", len(batch), err)
+		}
+
+		for _, j := range batch {
+			p.resolve(ctx, j.taskID, j.res)
+		}
+
+		return
+	}
+
+	for _, j := range batch {
+		j.res.status = statuses[j.taskID]
+		close(j.res.done)
+	}
+}
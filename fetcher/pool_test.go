@@ -0,0 +1,201 @@
+package fetcher
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/preslavmihaylov/todocheck/issuetracker/taskstatus"
+)
+
+func TestPoolPrefetchDeduplicatesTasks(t *testing.T) {
+	f := &countingFetcher{calls: map[string]int{}}
+	pool := NewPool(f, 4)
+
+	pool.Prefetch(context.Background(), []string{"1", "2", "1", "3", "2", "1"})
+	pool.Prefetch(context.Background(), []string{"3", "4"})
+
+	for _, taskID := range []string{"1", "2", "3", "4"} {
+		status, err := pool.Fetch(context.Background(), taskID)
+		if err != nil {
+			t.Errorf("Unexpected error for task %s: %v", taskID, err)
+		}
+		if status.Status != taskstatus.Open {
+			t.Errorf("Task %s status is %v, expected %v", taskID, status.Status, taskstatus.Open)
+		}
+		if f.calls[taskID] != 1 {
+			t.Errorf("Task %s was fetched %d times, expected 1", taskID, f.calls[taskID])
+		}
+	}
+}
+
+func TestPoolReset(t *testing.T) {
+	f := &countingFetcher{calls: map[string]int{}}
+	pool := NewPool(f, 1)
+
+	pool.Prefetch(context.Background(), []string{"1"})
+	pool.Reset()
+	if _, err := pool.Fetch(context.Background(), "1"); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+
+	if f.calls["1"] != 2 {
+		t.Errorf("Task was fetched %d times, expected 2", f.calls["1"])
+	}
+}
+
+func TestPoolPrefetchRespectsConcurrencyLimit(t *testing.T) {
+	const concurrency = 3
+	f := &countingFetcher{calls: map[string]int{}, delay: 10 * time.Millisecond}
+	pool := NewPool(f, concurrency)
+
+	pool.Prefetch(context.Background(), []string{"1", "2", "3", "4", "5", "6", "7", "8", "9", "10"})
+	if f.maxInFlight > concurrency {
+		t.Errorf("Max in-flight fetches is %d, expected at most %d", f.maxInFlight, concurrency)
+	}
+	if f.maxInFlight < 2 {
+		t.Errorf("Max in-flight fetches is %d, expected tasks to be fetched concurrently", f.maxInFlight)
+	}
+}
+
+func TestPoolFetchWithoutPrefetch(t *testing.T) {
+	f := &countingFetcher{calls: map[string]int{}}
+	pool := NewPool(f, 0)
+
+	for i := 0; i < 3; i++ {
+		if _, err := pool.Fetch(context.Background(), "FailedFetch"); err == nil {
+			t.Errorf("Expected fetch error, got nil")
+		}
+	}
+
+	if f.calls["FailedFetch"] != 1 {
+		t.Errorf("Task was fetched %d times, expected 1", f.calls["FailedFetch"])
+	}
+}
+
+func TestPoolPrefetchStopsWhenContextIsDone(t *testing.T) {
+	f := &countingFetcher{calls: map[string]int{}}
+	pool := NewPool(f, 2)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	pool.Prefetch(ctx, []string{"1", "2", "3"})
+	for _, taskID := range []string{"1", "2", "3"} {
+		if _, err := pool.Fetch(context.Background(), taskID); !errors.Is(err, context.Canceled) {
+			t.Errorf("Task %s error is %v, expected %v", taskID, err, context.Canceled)
+		}
+		if f.calls[taskID] != 0 {
+			t.Errorf("Task %s was fetched %d times, expected 0", taskID, f.calls[taskID])
+		}
+	}
+}
+
+type countingFetcher struct {
+	mu          sync.Mutex
+	calls       map[string]int
+	inFlight    int
+	maxInFlight int
+	delay       time.Duration
+}
+
+func (f *countingFetcher) Fetch(ctx context.Context, taskID string) (taskstatus.Result, error) {
+	f.mu.Lock()
+	f.calls[taskID]++
+	f.inFlight++
+	if f.inFlight > f.maxInFlight {
+		f.maxInFlight = f.inFlight
+	}
+	f.mu.Unlock()
+
+	time.Sleep(f.delay)
+
+	f.mu.Lock()
+	f.inFlight--
+	f.mu.Unlock()
+
+	if taskID == "FailedFetch" {
+		return taskstatus.Result{}, errTest
+	}
+
+	return taskstatus.Result{Status: taskstatus.Open}, nil
+}
+
+func TestPoolPrefetchInBatches(t *testing.T) {
+	f := &batchFetcher{countingFetcher: countingFetcher{calls: map[string]int{}}, batchSize: 2}
+	pool := NewPool(f, 2)
+
+	pool.Prefetch(context.Background(), []string{"1", "2", "3", "2", "4", "1"})
+	if len(f.batches) != 2 {
+		t.Errorf("Got %d batch lookups, expected 2: %v", len(f.batches), f.batches)
+	}
+
+	for _, taskID := range []string{"1", "2", "3", "4"} {
+		status, err := pool.Fetch(context.Background(), taskID)
+		if err != nil {
+			t.Errorf("Unexpected error for task %s: %v", taskID, err)
+		}
+		if status.Status != taskstatus.Closed {
+			t.Errorf("Task %s status is %v, expected %v", taskID, status.Status, taskstatus.Closed)
+		}
+		if f.calls[taskID] != 0 {
+			t.Errorf("Task %s was fetched individually %d times, expected 0", taskID, f.calls[taskID])
+		}
+	}
+}
+
+func TestPoolPrefetchFallsBackToSingleFetchesOnBatchFailure(t *testing.T) {
+	f := &batchFetcher{countingFetcher: countingFetcher{calls: map[string]int{}}, batchSize: 10}
+	pool := NewPool(f, 2)
+
+	pool.Prefetch(context.Background(), []string{"1", "FailedFetch", "3"})
+	if len(f.batches) != 1 {
+		t.Errorf("Got %d batch lookups, expected 1: %v", len(f.batches), f.batches)
+	}
+
+	if _, err := pool.Fetch(context.Background(), "FailedFetch"); err == nil {
+		t.Errorf("Expected fetch error, got nil")
+	}
+
+	for _, taskID := range []string{"1", "3"} {
+		status, err := pool.Fetch(context.Background(), taskID)
+		if err != nil {
+			t.Errorf("Unexpected error for task %s: %v", taskID, err)
+		}
+		if status.Status != taskstatus.Open {
+			t.Errorf("Task %s status is %v, expected %v", taskID, status.Status, taskstatus.Open)
+		}
+		if f.calls[taskID] != 1 {
+			t.Errorf("Task %s was fetched individually %d times, expected 1", taskID, f.calls[taskID])
+		}
+	}
+}
+
+type batchFetcher struct {
+	countingFetcher
+	batchSize int
+	batches   [][]string
+}
+
+func (f *batchFetcher) BatchSize() int {
+	return f.batchSize
+}
+
+func (f *batchFetcher) FetchBatch(ctx context.Context, taskIDs []string) (map[string]taskstatus.Result, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.batches = append(f.batches, taskIDs)
+	statuses := map[string]taskstatus.Result{}
+	for _, taskID := range taskIDs {
+		if taskID == "FailedFetch" {
+			return nil, errTest
+		}
+
+		statuses[taskID] = taskstatus.Result{Status: taskstatus.Closed}
+	}
+
+	return statuses, nil
+}
@@ -0,0 +1,204 @@
+package fetcher
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/preslavmihaylov/todocheck/logger"
+)
+
+// jiraResetTimeLayout is the ISO 8601 format Jira uses for its rate limit reset header
+const jiraResetTimeLayout = "2006-01-02T15:04Z07:00"
+
+// sendWithRetries sends the request & reads the response body.
+// Transient failures & rate-limited requests are retried based on the fetcher's retries configuration.
+// After the last retry, the final response or error is returned as is
+func (f *Fetcher) sendWithRetries(req *http.Request) (*http.Response, []byte, error) {
+	for retry := 0; ; retry++ {
+		resp, body, err := f.send(req)
+		if retry >= f.retries.MaxRetries || !isRetryable(req, resp, err) {
+			return resp, body, err
+		}
+
+		wait, reason := f.waitBeforeRetry(resp, err, retry)
+		if wait > f.retries.MaxWait {
+			logger.Infof("%s. Not retrying %s as the required wait of %s exceeds the max wait of %s\n",
+				reason, req.URL, wait.Round(time.Second), f.retries.MaxWait)
+			return resp, body, err
+		}
+
+		logger.Infof("%s. Retrying %s in %s (retry %d of %d)\n",
+			reason, req.URL, wait.Round(time.Millisecond), retry+1, f.retries.MaxRetries)
+		if err := f.sleep(req.Context(), wait); err != nil {
+			return nil, nil, fmt.Errorf("stopped waiting to retry %s: %w", req.URL, err)
+		}
+
+		if req.GetBody != nil {
+			req.Body, err = req.GetBody()
+			if err != nil {
+				return nil, nil, fmt.Errorf("couldn't rewind request body for retry: %w", err)
+			}
+		}
+	}
+}
+
+func (f *Fetcher) send(req *http.Request) (*http.Response, []byte, error) {
+	resp, err := f.sendRequest(req)
+	if err != nil {
+		return nil, nil, fmt.Errorf("couldn't execute %s request: %w", req.Method, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return resp, nil, fmt.Errorf("couldn't read response body: %w", err)
+	}
+
+	return resp, body, nil
+}
+
+// waitBeforeRetry returns how long to wait before retrying a request & the reason for retrying.
+// The issue tracker's rate limit headers take precedence over exponential backoff
+func (f *Fetcher) waitBeforeRetry(resp *http.Response, err error, retry int) (time.Duration, string) {
+	if err != nil {
+		return f.backoff(retry), fmt.Sprintf("Request failed: %s", err)
+	}
+
+	if wait, ok := retryAfter(resp.Header, f.now()); ok {
+		return wait, fmt.Sprintf("Issue tracker responded with status code %d & asked to retry after %s",
+			resp.StatusCode, wait.Round(time.Second))
+	}
+
+	if resetAt, ok := rateLimitReset(resp.Header); ok && isRateLimitExhausted(resp.Header) {
+		wait := resetAt.Sub(f.now())
+		if wait < 0 {
+			wait = 0
+		}
+
+		return wait, fmt.Sprintf("Issue tracker rate limit is exhausted until %s", resetAt.Format(time.RFC3339))
+	}
+
+	return f.backoff(retry), fmt.Sprintf("Issue tracker responded with status code %d", resp.StatusCode)
+}
+
+// backoff returns an exponentially increasing wait with jitter, bounded by the configured min & max backoff
+func (f *Fetcher) backoff(retry int) time.Duration {
+	wait := f.retries.MinBackoff
+	for i := 0; i < retry && wait < f.retries.MaxBackoff; i++ {
+		wait *= 2
+	}
+
+	if wait > f.retries.MaxBackoff {
+		wait = f.retries.MaxBackoff
+	}
+
+	if wait <= 0 {
+		return 0
+	}
+
+	return f.jitter(wait)
+}
+
+// equalJitter randomizes the given wait, keeping at least half of it,
+// so that concurrent requests which failed together don't retry together
+func equalJitter(wait time.Duration) time.Duration {
+	return wait/2 + time.Duration(rand.Int63n(int64(wait/2)+1))
+}
+
+// sleepContext waits for the given duration or until the context is done, whichever comes first
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func isRetryable(req *http.Request, resp *http.Response, err error) bool {
+	if req.Context().Err() != nil {
+		// the run was cancelled or timed out, so there's no point in retrying
+		return false
+	} else if err != nil {
+		return true
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests,
+		http.StatusInternalServerError,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true
+	case http.StatusForbidden:
+		// github responds with 403 when its primary rate limit is exhausted
+		return isRateLimitExhausted(resp.Header)
+	default:
+		return false
+	}
+}
+
+// isRateLimitExhausted checks the rate limit headers used by github & jira (X-RateLimit-*) and gitlab (RateLimit-*)
+func isRateLimitExhausted(header http.Header) bool {
+	remaining := header.Get("X-RateLimit-Remaining")
+	if remaining == "" {
+		remaining = header.Get("RateLimit-Remaining")
+	}
+
+	return remaining == "0"
+}
+
+// rateLimitReset parses the time at which the rate limit resets.
+// Github & Gitlab specify it in unix epoch seconds, while Jira uses an ISO 8601 timestamp
+func rateLimitReset(header http.Header) (time.Time, bool) {
+	reset := header.Get("X-RateLimit-Reset")
+	if reset == "" {
+		reset = header.Get("RateLimit-Reset")
+	}
+
+	if reset == "" {
+		return time.Time{}, false
+	}
+
+	if epochSecs, err := strconv.ParseInt(reset, 10, 64); err == nil {
+		return time.Unix(epochSecs, 0), true
+	}
+
+	for _, layout := range []string{time.RFC3339, jiraResetTimeLayout} {
+		if t, err := time.Parse(layout, reset); err == nil {
+			return t, true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+// retryAfter parses the Retry-After header, which is either in seconds or an HTTP date
+func retryAfter(header http.Header, now time.Time) (time.Duration, bool) {
+	value := header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+
+	if secs, err := strconv.Atoi(value); err == nil && secs >= 0 {
+		return time.Duration(secs) * time.Second, true
+	}
+
+	if t, err := http.ParseTime(value); err == nil {
+		if t.Before(now) {
+			return 0, true
+		}
+
+		return t.Sub(now), true
+	}
+
+	return 0, false
+}
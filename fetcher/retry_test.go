@@ -0,0 +1,260 @@
+package fetcher
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/preslavmihaylov/todocheck/config"
+	"github.com/preslavmihaylov/todocheck/issuetracker/taskstatus"
+)
+
+var testNow = time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC)
+
+func TestFetchRetries(t *testing.T) {
+	testData := []struct {
+		Name      string
+		Responses []mockResponse
+		Status    taskstatus.TaskStatus
+		Waits     []time.Duration
+		WantErr   bool
+	}{
+		{
+			Name:      "NoRetryOnSuccess",
+			Responses: []mockResponse{{StatusCode: 200}},
+			Status:    taskstatus.Open,
+		},
+		{
+			Name:      "NoRetryOnNotFound",
+			Responses: []mockResponse{{StatusCode: 404}},
+			Status:    taskstatus.NonExistent,
+		},
+		{
+			Name:      "NoRetryOnBadRequest",
+			Responses: []mockResponse{{StatusCode: 400}},
+			WantErr:   true,
+		},
+		{
+			Name: "RetryAfterSeconds",
+			Responses: []mockResponse{
+				{StatusCode: 429, Header: http.Header{"Retry-After": {"5"}}},
+				{StatusCode: 200},
+			},
+			Status: taskstatus.Open,
+			Waits:  []time.Duration{5 * time.Second},
+		},
+		{
+			Name: "RetryAfterHTTPDate",
+			Responses: []mockResponse{
+				{StatusCode: 503, Header: http.Header{"Retry-After": {testNow.Add(10 * time.Second).Format(http.TimeFormat)}}},
+				{StatusCode: 200},
+			},
+			Status: taskstatus.Open,
+			Waits:  []time.Duration{10 * time.Second},
+		},
+		{
+			Name: "GithubRateLimitReset",
+			Responses: []mockResponse{
+				{StatusCode: 403, Header: http.Header{
+					"X-Ratelimit-Remaining": {"0"},
+					"X-Ratelimit-Reset":     {"1577880020"},
+				}},
+				{StatusCode: 200},
+			},
+			Status: taskstatus.Open,
+			Waits:  []time.Duration{20 * time.Second},
+		},
+		{
+			Name: "GitlabRateLimitReset",
+			Responses: []mockResponse{
+				{StatusCode: 429, Header: http.Header{
+					"Ratelimit-Remaining": {"0"},
+					"Ratelimit-Reset":     {"1577880030"},
+				}},
+				{StatusCode: 200},
+			},
+			Status: taskstatus.Open,
+			Waits:  []time.Duration{30 * time.Second},
+		},
+		{
+			Name: "JiraRateLimitReset",
+			Responses: []mockResponse{
+				{StatusCode: 429, Header: http.Header{
+					"X-Ratelimit-Remaining": {"0"},
+					"X-Ratelimit-Reset":     {"2020-01-01T12:01Z"},
+				}},
+				{StatusCode: 200},
+			},
+			Status: taskstatus.Open,
+			Waits:  []time.Duration{time.Minute},
+		},
+		{
+			Name: "NoRetryOnForbiddenWithRemainingRateLimit",
+			Responses: []mockResponse{
+				{StatusCode: 403, Header: http.Header{"X-Ratelimit-Remaining": {"10"}}},
+			},
+			WantErr: true,
+		},
+		{
+			Name: "GiveUpWhenWaitExceedsMaxWait",
+			Responses: []mockResponse{
+				{StatusCode: 429, Header: http.Header{"Retry-After": {"3600"}}},
+			},
+			WantErr: true,
+		},
+		{
+			Name: "GiveUpAfterMaxRetries",
+			Responses: []mockResponse{
+				{StatusCode: 502},
+				{StatusCode: 502},
+				{StatusCode: 502},
+				{StatusCode: 502},
+			},
+			Waits:   []time.Duration{time.Second, 2 * time.Second, 3 * time.Second},
+			WantErr: true,
+		},
+		{
+			Name: "BackoffIsCappedAtMaxBackoff",
+			Responses: []mockResponse{
+				{Err: errTest},
+				{Err: errTest},
+				{Err: errTest},
+				{StatusCode: 200},
+			},
+			Status: taskstatus.Open,
+			Waits:  []time.Duration{time.Second, 2 * time.Second, 3 * time.Second},
+		},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.Name, func(t *testing.T) {
+			f, client, waits := newRetryingFetcher(t, tt.Responses)
+			status, err := f.Fetch(context.Background(), "RetriedFetch")
+			if tt.WantErr && err == nil {
+				t.Errorf("Expected error, got nil")
+			} else if !tt.WantErr && err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+
+			if status.Status != tt.Status {
+				t.Errorf("Status is %v, expected %v", status.Status, tt.Status)
+			}
+
+			if client.calls != len(tt.Responses) {
+				t.Errorf("Sent %d requests, expected %d", client.calls, len(tt.Responses))
+			}
+
+			if len(*waits) != len(tt.Waits) {
+				t.Fatalf("Waited %v, expected %v", *waits, tt.Waits)
+			}
+
+			for i := range tt.Waits {
+				if (*waits)[i] != tt.Waits[i] {
+					t.Errorf("Wait #%d is %s, expected %s", i+1, (*waits)[i], tt.Waits[i])
+				}
+			}
+		})
+	}
+}
+
+func TestFetchWithoutRetriesConfig(t *testing.T) {
+	f := NewFetcher(mockIssueTracker{}, nil, &config.Local{})
+	client := &sequenceClient{t: t, responses: []mockResponse{{StatusCode: 429}, {StatusCode: 200}}}
+	f.sendRequest = client.sendRequest
+
+	if _, err := f.Fetch(context.Background(), "RetriedFetch"); err == nil {
+		t.Errorf("Expected error, got nil")
+	}
+
+	if client.calls != 1 {
+		t.Errorf("Sent %d requests, expected 1", client.calls)
+	}
+}
+
+func TestFetchStopsRetryingWhenContextIsDone(t *testing.T) {
+	f, client, _ := newRetryingFetcher(t, []mockResponse{{StatusCode: 503}})
+	ctx, cancel := context.WithCancel(context.Background())
+	f.sleep = func(ctx context.Context, d time.Duration) error {
+		cancel()
+		return sleepContext(ctx, d)
+	}
+
+	if _, err := f.Fetch(ctx, "RetriedFetch"); !errors.Is(err, context.Canceled) {
+		t.Errorf("Error is %v, expected %v", err, context.Canceled)
+	}
+
+	if client.calls != 1 {
+		t.Errorf("Sent %d requests, expected 1", client.calls)
+	}
+}
+
+func TestBackoffJitter(t *testing.T) {
+	f := NewFetcher(mockIssueTracker{}, nil, &config.Local{Retries: &config.Retries{MinBackoff: time.Second, MaxBackoff: 10 * time.Second}})
+	for retry, max := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second, 10 * time.Second} {
+		for i := 0; i < 20; i++ {
+			wait := f.backoff(retry)
+			if wait < max/2 || wait > max {
+				t.Errorf("Backoff for retry %d is %s, expected it to be between %s & %s", retry, wait, max/2, max)
+			}
+		}
+	}
+}
+
+// newRetryingFetcher returns a fetcher without backoff jitter, which records the waits in between retries instead of sleeping
+func newRetryingFetcher(t *testing.T, responses []mockResponse) (*Fetcher, *sequenceClient, *[]time.Duration) {
+	f := NewFetcher(mockIssueTracker{}, nil, &config.Local{Retries: &config.Retries{
+		MaxRetries: 3,
+		MinBackoff: time.Second,
+		MaxBackoff: 3 * time.Second,
+		MaxWait:    2 * time.Minute,
+	}})
+
+	client := &sequenceClient{t: t, responses: responses}
+	waits := &[]time.Duration{}
+	f.sendRequest = client.sendRequest
+	f.now = func() time.Time { return testNow }
+	f.sleep = func(ctx context.Context, d time.Duration) error {
+		*waits = append(*waits, d)
+		return nil
+	}
+	f.jitter = func(d time.Duration) time.Duration { return d }
+
+	return f, client, waits
+}
+
+type mockResponse struct {
+	StatusCode int
+	Header     http.Header
+	Err        error
+}
+
+// sequenceClient responds with the given responses in order
+type sequenceClient struct {
+	t         *testing.T
+	responses []mockResponse
+	calls     int
+}
+
+func (c *sequenceClient) sendRequest(req *http.Request) (*http.Response, error) {
+	if c.calls >= len(c.responses) {
+		c.t.Fatalf("Unexpected request #%d", c.calls+1)
+	}
+
+	r := c.responses[c.calls]
+	c.calls++
+	if r.Err != nil {
+		return nil, r.Err
+	}
+
+	body, _ := json.Marshal(mockTask{})
+	return &http.Response{
+		StatusCode: r.StatusCode,
+		Header:     r.Header,
+		Body:       io.NopCloser(bytes.NewReader(body)),
+	}, nil
+}
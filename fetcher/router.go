@@ -0,0 +1,171 @@
+package fetcher
+
+import (
+	"context"
+	"sync"
+
+	"github.com/preslavmihaylov/todocheck/config"
+	"github.com/preslavmihaylov/todocheck/issuetracker/taskstatus"
+)
+
+// Router fetches task statuses from the issue tracker, each task belongs to.
+// Task IDs of named issue trackers are prefixed with the tracker's name, e.g. gh:#12. Other task IDs belong to the default issue tracker
+type Router struct {
+	defaultFetcher StatusFetcher
+	fetchers       map[string]StatusFetcher
+}
+
+// NewRouter instance, which fetches the tasks of the default issue tracker via defaultFetcher
+// & the tasks of named issue trackers via the fetcher for the tracker's name
+func NewRouter(defaultFetcher StatusFetcher, fetchers map[string]StatusFetcher) *Router {
+	return &Router{defaultFetcher, fetchers}
+}
+
+// Fetch a task's status from the issue tracker it belongs to.
+// The task ID, the task links to, is prefixed with the same issue tracker's name
+func (r *Router) Fetch(ctx context.Context, taskID string) (taskstatus.Result, error) {
+	name, f, unprefixed := r.fetcherFor(taskID)
+	status, err := f.Fetch(ctx, unprefixed)
+	if err != nil {
+		return status, err
+	}
+
+	return withTrackerPrefix(name, status), nil
+}
+
+// BatchSize returns the largest batch size among the issue trackers' fetchers or zero if none of them supports batch lookups
+func (r *Router) BatchSize() int {
+	batchSize := batchSizeOf(r.defaultFetcher)
+	for _, f := range r.fetchers {
+		if size := batchSizeOf(f); size > batchSize {
+			batchSize = size
+		}
+	}
+
+	return batchSize
+}
+
+// FetchBatch fetches the statuses of the given tasks, grouped by the issue tracker they belong to.
+// The groups are fetched concurrently & the tasks of issue trackers, which don't support batch lookups, are fetched concurrently one by one.
+// Wrap the issue trackers' fetchers in a Limiter to limit the concurrent lookups of each issue tracker
+func (r *Router) FetchBatch(ctx context.Context, taskIDs []string) (map[string]taskstatus.Result, error) {
+	var names []string
+	groups := map[string][]string{}
+	for _, taskID := range taskIDs {
+		name, _ := config.SplitTrackerPrefix(taskID)
+		if _, ok := r.fetchers[name]; !ok {
+			name = ""
+		}
+
+		if _, ok := groups[name]; !ok {
+			names = append(names, name)
+		}
+
+		groups[name] = append(groups[name], taskID)
+	}
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		statuses = map[string]taskstatus.Result{}
+		firstErr error
+	)
+
+	collect := func(taskID string, status taskstatus.Result, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if err != nil && firstErr == nil {
+			firstErr = err
+		} else if err == nil {
+			statuses[taskID] = status
+		}
+	}
+
+	for _, name := range names {
+		wg.Add(1)
+		go func(taskIDs []string) {
+			defer wg.Done()
+			r.fetchGroup(ctx, taskIDs, collect)
+		}(groups[name])
+	}
+
+	wg.Wait()
+	if firstErr != nil {
+		return nil, firstErr
+	}
+
+	return statuses, nil
+}
+
+// fetchGroup fetches the statuses of the given tasks, which belong to the same issue tracker, & passes each result to collect
+func (r *Router) fetchGroup(ctx context.Context, taskIDs []string, collect func(taskID string, status taskstatus.Result, err error)) {
+	name, f, _ := r.fetcherFor(taskIDs[0])
+	unprefixed := map[string]string{}
+	for _, taskID := range taskIDs {
+		_, _, unprefixed[taskID] = r.fetcherFor(taskID)
+	}
+
+	bf, ok := f.(BatchStatusFetcher)
+	if !ok || bf.BatchSize() == 0 {
+		var wg sync.WaitGroup
+		for _, taskID := range taskIDs {
+			wg.Add(1)
+			go func(taskID string) {
+				defer wg.Done()
+				status, err := f.Fetch(ctx, unprefixed[taskID])
+				collect(taskID, withTrackerPrefix(name, status), err)
+			}(taskID)
+		}
+
+		wg.Wait()
+		return
+	}
+
+	for len(taskIDs) > 0 {
+		batch := taskIDs[:min(len(taskIDs), bf.BatchSize())]
+		taskIDs = taskIDs[len(batch):]
+
+		batchIDs := make([]string, len(batch))
+		for i, taskID := range batch {
+			batchIDs[i] = unprefixed[taskID]
+		}
+
+		fetched, err := bf.FetchBatch(ctx, batchIDs)
+		if err != nil {
+			collect(batch[0], taskstatus.Result{}, err)
+			return
+		}
+
+		for _, taskID := range batch {
+			collect(taskID, withTrackerPrefix(name, fetched[unprefixed[taskID]]), nil)
+		}
+	}
+}
+
+// fetcherFor the given task. Returns the name of the issue tracker, the task belongs to, its fetcher & the task ID without the tracker's prefix
+func (r *Router) fetcherFor(taskID string) (name string, f StatusFetcher, unprefixed string) {
+	name, unprefixed = config.SplitTrackerPrefix(taskID)
+	if f, ok := r.fetchers[name]; ok && name != "" {
+		return name, f, unprefixed
+	}
+
+	return "", r.defaultFetcher, taskID
+}
+
+func batchSizeOf(f StatusFetcher) int {
+	if bf, ok := f.(BatchStatusFetcher); ok {
+		return bf.BatchSize()
+	}
+
+	return 0
+}
+
+// withTrackerPrefix prefixes the task ID, the given status links to, with the name of the issue tracker it was fetched from
+func withTrackerPrefix(name string, status taskstatus.Result) taskstatus.Result {
+	if status.LinkedTaskID != "" {
+		status.LinkedTaskID = config.WithTrackerPrefix(name, status.LinkedTaskID)
+	}
+
+	return status
+}
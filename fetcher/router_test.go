@@ -0,0 +1,104 @@
+package fetcher
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/preslavmihaylov/todocheck/issuetracker/taskstatus"
+)
+
+func TestRouterFetchesFromIssueTrackerOfTask(t *testing.T) {
+	defaultFetcher := &countingFetcher{calls: map[string]int{}}
+	named := &linkingFetcher{}
+	r := NewRouter(defaultFetcher, map[string]StatusFetcher{"gh": named})
+
+	status, err := r.Fetch(context.Background(), "gh:#12")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if status.Status != taskstatus.ClosedDuplicate || status.LinkedTaskID != "gh:#1" {
+		t.Errorf("Task gh:#12 status is %+v, expected a duplicate of task gh:#1", status)
+	}
+	if !reflect.DeepEqual(named.fetched, []string{"#12"}) {
+		t.Errorf("Named tracker fetched %v, expected [#12]", named.fetched)
+	}
+
+	for _, taskID := range []string{"ABC-12", "jira:ABC-12"} {
+		if _, err := r.Fetch(context.Background(), taskID); err != nil {
+			t.Errorf("Unexpected error for task %s: %v", taskID, err)
+		}
+		if defaultFetcher.calls[taskID] != 1 {
+			t.Errorf("Task %s was fetched %d times from the default tracker, expected 1", taskID, defaultFetcher.calls[taskID])
+		}
+	}
+}
+
+func TestRouterFetchBatchGroupsTasksByIssueTracker(t *testing.T) {
+	defaultFetcher := &batchFetcher{countingFetcher: countingFetcher{calls: map[string]int{}}, batchSize: 2}
+	named := &countingFetcher{calls: map[string]int{}}
+	r := NewRouter(defaultFetcher, map[string]StatusFetcher{"gh": named})
+
+	if r.BatchSize() != 2 {
+		t.Errorf("Batch size is %d, expected 2", r.BatchSize())
+	}
+
+	statuses, err := r.FetchBatch(context.Background(), []string{"1", "gh:#1", "2", "3", "gh:#2"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if !reflect.DeepEqual(defaultFetcher.batches, [][]string{{"1", "2"}, {"3"}}) {
+		t.Errorf("Got batch lookups %v, expected [[1 2] [3]]", defaultFetcher.batches)
+	}
+
+	want := map[string]taskstatus.TaskStatus{
+		"1":     taskstatus.Closed,
+		"2":     taskstatus.Closed,
+		"3":     taskstatus.Closed,
+		"gh:#1": taskstatus.Open,
+		"gh:#2": taskstatus.Open,
+	}
+	for taskID, wantStatus := range want {
+		if statuses[taskID].Status != wantStatus {
+			t.Errorf("Task %s status is %v, expected %v", taskID, statuses[taskID].Status, wantStatus)
+		}
+	}
+
+	if named.calls["#1"] != 1 || named.calls["#2"] != 1 {
+		t.Errorf("Named tracker's tasks were fetched %v, expected once each", named.calls)
+	}
+}
+
+func TestRouterFetchBatchLimitsConcurrencyOfIssueTracker(t *testing.T) {
+	const concurrency = 2
+	defaultFetcher := &batchFetcher{countingFetcher: countingFetcher{calls: map[string]int{}}, batchSize: 10}
+	named := &countingFetcher{calls: map[string]int{}, delay: 10 * time.Millisecond}
+	r := NewRouter(defaultFetcher, map[string]StatusFetcher{"gh": NewLimiter(named, concurrency)})
+
+	if _, err := r.FetchBatch(context.Background(), []string{"1", "gh:#1", "gh:#2", "gh:#3", "gh:#4", "gh:#5"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if named.maxInFlight != concurrency {
+		t.Errorf("Max in-flight fetches of the named tracker is %d, expected %d", named.maxInFlight, concurrency)
+	}
+}
+
+func TestRouterFetchBatchReturnsError(t *testing.T) {
+	r := NewRouter(&countingFetcher{calls: map[string]int{}}, map[string]StatusFetcher{"gh": &countingFetcher{calls: map[string]int{}}})
+	if _, err := r.FetchBatch(context.Background(), []string{"1", "gh:FailedFetch"}); !errors.Is(err, errTest) {
+		t.Errorf("Got error %v, expected %v", err, errTest)
+	}
+}
+
+type linkingFetcher struct {
+	fetched []string
+}
+
+func (f *linkingFetcher) Fetch(ctx context.Context, taskID string) (taskstatus.Result, error) {
+	f.fetched = append(f.fetched, taskID)
+	return taskstatus.Result{Status: taskstatus.ClosedDuplicate, LinkedTaskID: "#1"}, nil
+}
@@ -0,0 +1,114 @@
+// Package githook installs todocheck as a git pre-commit hook
+package githook
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// Command the installed hook runs. It only checks the staged content of the commit
+const Command = "todocheck --staged"
+
+// marker identifies the todocheck section of a hook, so that installing the hook again doesn't duplicate it
+const marker = "# Added by todocheck install-hook"
+
+// DefaultFilePermissions the hook is created with. It must be executable for git to run it
+var DefaultFilePermissions = os.FileMode(0755)
+
+// Install the pre-commit hook in the git repository, which contains dir & return the path to the hook.
+// The content of an existing hook is kept & the todocheck section is inserted at its beginning
+func Install(ctx context.Context, dir string) (string, error) {
+	hooksDir, err := hooksPath(ctx, dir)
+	if err != nil {
+		return "", err
+	}
+
+	filename := filepath.Join(hooksDir, "pre-commit")
+	existing, err := os.ReadFile(filename)
+	if err != nil && !os.IsNotExist(err) {
+		return "", fmt.Errorf("couldn't read existing hook %s: %w", filename, err)
+	}
+
+	content, err := hookWith(string(existing))
+	if err != nil {
+		return "", fmt.Errorf("couldn't install hook %s: %w", filename, err)
+	}
+
+	if err := os.MkdirAll(hooksDir, 0755); err != nil {
+		return "", fmt.Errorf("couldn't create hooks directory %s: %w", hooksDir, err)
+	}
+
+	if err := os.WriteFile(filename, []byte(content), DefaultFilePermissions); err != nil {
+		return "", fmt.Errorf("couldn't write hook %s: %w", filename, err)
+	}
+
+	// WriteFile doesn't change the permissions of an existing file
+	if err := os.Chmod(filename, DefaultFilePermissions); err != nil {
+		return "", fmt.Errorf("couldn't make hook %s executable: %w", filename, err)
+	}
+
+	return filename, nil
+}
+
+// hookWith returns the given hook with the todocheck section inserted right after its shebang,
+// so that it runs even if the rest of the hook exits early, e.g. via `exit 0` or `exec`.
+// Hooks, which aren't shell scripts, are not modified, as the section would break them
+func hookWith(existing string) (string, error) {
+	section := marker + "\n" + Command + " || exit $?\n"
+	if strings.TrimSpace(existing) == "" {
+		return "#!/bin/sh\n\n" + section, nil
+	} else if strings.Contains(existing, marker) {
+		return existing, nil
+	}
+
+	if !isShellScript(existing) {
+		return "", fmt.Errorf("existing hook is not a shell script. Add `%s` to it manually", Command)
+	}
+
+	shebang, rest, _ := strings.Cut(existing, "\n")
+	if rest = strings.TrimLeft(rest, "\n"); rest != "" {
+		section += "\n"
+	}
+
+	return shebang + "\n\n" + section + rest, nil
+}
+
+// isShellScript returns true if the given script's shebang points at a shell, e.g. #!/bin/sh or #!/usr/bin/env bash
+func isShellScript(script string) bool {
+	shebang, _, _ := strings.Cut(script, "\n")
+	if !strings.HasPrefix(shebang, "#!") {
+		return false
+	}
+
+	for _, field := range strings.Fields(strings.TrimPrefix(shebang, "#!")) {
+		switch filepath.Base(field) {
+		case "sh", "bash", "dash", "zsh", "ksh":
+			return true
+		}
+	}
+
+	return false
+}
+
+// hooksPath returns the hooks directory of the git repository, which contains dir. It respects core.hooksPath & worktrees
+func hooksPath(ctx context.Context, dir string) (string, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "git", "-C", dir, "rev-parse", "--git-path", "hooks")
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("couldn't find git hooks directory: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	hooksDir := strings.TrimSpace(stdout.String())
+	if !filepath.IsAbs(hooksDir) {
+		hooksDir = filepath.Join(dir, hooksDir)
+	}
+
+	return hooksDir, nil
+}
@@ -0,0 +1,75 @@
+package githook
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestHookWith(t *testing.T) {
+	section := marker + "\n" + Command + " || exit $?\n"
+	tests := []struct {
+		existing, want string
+		wantErr        bool
+	}{
+		{"", "#!/bin/sh\n\n" + section, false},
+		{"#!/bin/bash\nmake lint", "#!/bin/bash\n\n" + section + "\nmake lint", false},
+		{"#!/usr/bin/env bash\n\nmake lint\n", "#!/usr/bin/env bash\n\n" + section + "\nmake lint\n", false},
+		{"#!/bin/sh\nmake lint\nexit 0\n", "#!/bin/sh\n\n" + section + "\nmake lint\nexit 0\n", false},
+		{"#!/bin/sh", "#!/bin/sh\n\n" + section, false},
+		{"#!/bin/sh\n\n" + section, "#!/bin/sh\n\n" + section, false},
+		{"#!/usr/bin/env python3\nprint('lint')\n", "", true},
+		{"make lint\n", "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := hookWith(tt.existing)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("Got error %v for hook %q, expected error: %v", err, tt.existing, tt.wantErr)
+		} else if got != tt.want {
+			t.Errorf("Got hook %q for %q, expected %q", got, tt.existing, tt.want)
+		}
+	}
+}
+
+func TestInstall(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	dir := t.TempDir()
+	if out, err := exec.Command("git", "init", "-q", dir).CombinedOutput(); err != nil {
+		t.Fatalf("git init failed: %v: %s", err, out)
+	}
+
+	hooksDir := filepath.Join(dir, ".git", "hooks")
+	if err := os.MkdirAll(hooksDir, 0755); err != nil {
+		t.Fatalf("Couldn't create hooks directory: %v", err)
+	}
+
+	existing := "#!/bin/sh\nmake lint\n"
+	if err := os.WriteFile(filepath.Join(hooksDir, "pre-commit"), []byte(existing), 0644); err != nil {
+		t.Fatalf("Couldn't write existing hook: %v", err)
+	}
+
+	filename, err := Install(context.Background(), dir)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	bs, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatalf("Couldn't read hook: %v", err)
+	}
+
+	if !strings.HasSuffix(string(bs), "make lint\n") || !strings.Contains(string(bs), Command) {
+		t.Errorf("Expected hook to keep the existing content & run %s, got %q", Command, bs)
+	}
+
+	if info, err := os.Stat(filename); err != nil || info.Mode().Perm()&0100 == 0 {
+		t.Errorf("Expected hook %s to be executable", filename)
+	}
+}
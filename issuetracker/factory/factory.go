@@ -1,6 +1,7 @@
 package factory
 
 import (
+	"context"
 	"errors"
 
 	"github.com/preslavmihaylov/todocheck/issuetracker/internal/azureboards"
@@ -17,12 +18,12 @@ import (
 
 // NewIssueTrackerFrom is a static factory method for creating an issuetracker.IssueTracker instance based on the chosen issue tracker type
 // in the configuration
-func NewIssueTrackerFrom(issueTrackerType config.IssueTracker, authCfg *config.Auth, origin string) (issuetracker.IssueTracker, error) {
+func NewIssueTrackerFrom(ctx context.Context, issueTrackerType config.IssueTracker, authCfg *config.Auth, origin string) (issuetracker.IssueTracker, error) {
 	switch issueTrackerType {
 	case config.IssueTrackerGithub:
 		return github.New(origin, authCfg)
 	case config.IssueTrackerJira:
-		return jira.New(origin, authCfg)
+		return jira.New(ctx, origin, authCfg)
 	case config.IssueTrackerGitlab:
 		return gitlab.New(origin, authCfg)
 	case config.IssueTrackerRedmine:
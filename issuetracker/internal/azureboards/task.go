@@ -8,7 +8,12 @@ import (
 type Task struct {
 	ID     int `json:"id"`
 	Fields struct {
-		State string `json:"System.State"`
+		Title      string `json:"System.Title"`
+		State      string `json:"System.State"`
+		AssignedTo *struct {
+			DisplayName string `json:"displayName"`
+			UniqueName  string `json:"uniqueName"`
+		} `json:"System.AssignedTo"`
 	}
 }
 
@@ -21,3 +26,22 @@ func (t *Task) GetStatus() (taskstatus.TaskStatus, error) {
 		return taskstatus.Open, nil
 	}
 }
+
+// RawStatuses of azure boards task, which is its work item state
+func (t *Task) RawStatuses() []string {
+	return []string{t.Fields.State}
+}
+
+// Title of azure boards task
+func (t *Task) Title() string {
+	return t.Fields.Title
+}
+
+// Assignees of azure boards task. A work item has a single assignee, identified by its display name & unique name
+func (t *Task) Assignees() []string {
+	if t.Fields.AssignedTo == nil {
+		return nil
+	}
+
+	return []string{t.Fields.AssignedTo.DisplayName, t.Fields.AssignedTo.UniqueName}
+}
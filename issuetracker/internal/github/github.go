@@ -1,8 +1,11 @@
 package github
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
 
 	"github.com/preslavmihaylov/todocheck/common"
@@ -10,6 +13,14 @@ import (
 	"github.com/preslavmihaylov/todocheck/issuetracker"
 )
 
+const maxBatchSize = 50
+
+// originRepositoryField is the field of the batch query, which looks up issues in the origin repository
+const originRepositoryField = "repository"
+
+// assigneesQuery is the GraphQL selection of an issue's or pull request's assignees
+const assigneesQuery = "assignees(first: 10) { nodes { login } }"
+
 // New creates a new github issuetracker instance
 func New(origin string, authCfg *config.Auth) (*IssueTracker, error) {
 	return &IssueTracker{origin, authCfg}, nil
@@ -26,9 +37,16 @@ func (it *IssueTracker) TaskModel() issuetracker.Task {
 	return &Task{}
 }
 
-// IssueURLFor Returns the full URL for the github issue
+// IssueURLFor Returns the full URL for the github issue.
+// Issues in other repositories, referenced via owner/repo#number, are looked up in the given repository
 func (it *IssueTracker) IssueURLFor(taskID string) string {
-	return it.issueAPIOrigin() + it.taskURLFrom(taskID)
+	repo, taskID := config.SplitCrossRepoRef(taskID)
+	if repo == "" {
+		return it.issueAPIOrigin() + it.taskURLFrom(taskID)
+	}
+
+	scheme, _, _ := it.urlTokensFromOrigin()
+	return fmt.Sprintf("%s//api.github.com/repos/%s/issues/%s", scheme, strings.ToLower(repo), it.taskURLFrom(taskID))
 }
 
 // Exists verifies if the issue tracker exists based on the provided configuration
@@ -61,6 +79,164 @@ func (it *IssueTracker) TokenAcquisitionInstructions() string {
 	return "Please go to https://github.com/settings/tokens, create a read-only access token & paste it here."
 }
 
+// MaxBatchSize returns the max amount of issues looked up in a single GraphQL query.
+// Github's GraphQL API requires authentication, hence batch lookups are only available with an api token
+func (it *IssueTracker) MaxBatchSize() int {
+	if it.AuthCfg == nil || it.AuthCfg.Type != config.AuthTypeAPIToken {
+		return 0
+	}
+
+	return maxBatchSize
+}
+
+// BatchRequestFor returns a GraphQL query request for all of the given issues.
+// Each issue is looked up via an aliased issueOrPullRequest field, as the REST API treats pull requests as issues as well.
+// Issues in other repositories are looked up via aliased repository fields
+func (it *IssueTracker) BatchRequestFor(taskIDs []string) (*http.Request, error) {
+	scheme, owner, repo := it.urlTokensFromOrigin()
+	aliases, repos := it.repositoryAliases(taskIDs)
+
+	var (
+		order  []string
+		fields = map[string]*strings.Builder{}
+	)
+
+	for i, taskID := range taskIDs {
+		_, ref := config.SplitCrossRepoRef(taskID)
+		number, err := strconv.Atoi(it.taskURLFrom(ref))
+		if err != nil {
+			return nil, fmt.Errorf("invalid github issue number %q", taskID)
+		}
+
+		alias := aliases[i]
+		if fields[alias] == nil {
+			order = append(order, alias)
+			fields[alias] = &strings.Builder{}
+		}
+
+		fmt.Fprintf(fields[alias], "i%d: issueOrPullRequest(number: %d) { ... on Issue { title state stateReason %[3]s } ... on PullRequest { title state %[3]s } } ", i, number, assigneesQuery)
+	}
+
+	var query strings.Builder
+	query.WriteString("query { ")
+	for _, alias := range order {
+		repoOwner, repoName := owner, repo
+		if alias != originRepositoryField {
+			repoOwner, repoName, _ = strings.Cut(repos[alias], "/")
+			query.WriteString(alias + ": ")
+		}
+
+		fmt.Fprintf(&query, "repository(owner: %s, name: %s) { %s} ", strconv.Quote(repoOwner), strconv.Quote(repoName), fields[alias].String())
+	}
+	query.WriteString("}")
+
+	body, err := json.Marshal(map[string]string{"query": query.String()})
+	if err != nil {
+		return nil, fmt.Errorf("couldn't marshal graphql query: %w", err)
+	}
+
+	req, err := http.NewRequest("POST", fmt.Sprintf("%s//api.github.com/graphql", scheme), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	return req, nil
+}
+
+// TasksFromBatchResponse extracts the found issues from a GraphQL query response, keyed by the given task IDs.
+// Issues in other repositories, which weren't found, are missing from the result, so that they are fetched one by one
+func (it *IssueTracker) TasksFromBatchResponse(taskIDs []string, body []byte) (map[string]issuetracker.Task, error) {
+	var res graphqlResult
+	if err := json.Unmarshal(body, &res); err != nil {
+		return nil, fmt.Errorf("couldn't unmarshal graphql result JSON: %w", err)
+	}
+
+	aliases, _ := it.repositoryAliases(taskIDs)
+	tasks := map[string]issuetracker.Task{}
+	for i, taskID := range taskIDs {
+		repository := res.Data[aliases[i]]
+		if repository == nil && aliases[i] == originRepositoryField {
+			return nil, fmt.Errorf("repository not found in graphql result: %s", string(body))
+		}
+
+		issue := repository[fmt.Sprintf("i%d", i)]
+		if issue == nil {
+			continue
+		}
+
+		// merged pull requests are reported as closed ones by the REST API
+		state := strings.ToLower(issue.State)
+		if state == "merged" {
+			state = "closed"
+		}
+
+		tasks[taskID] = &Task{
+			State:       state,
+			StateReason: strings.ToLower(issue.StateReason),
+			AssignedTo:  issue.Assignees.Nodes,
+			IssueTitle:  issue.Title,
+		}
+	}
+
+	return tasks, nil
+}
+
+// MovedTaskID returns the new reference of the fetched task in the form owner/repo#number, if it was transferred to another repository.
+// Github redirects requests for transferred issues to the new issue, hence its URL points to a different repository
+func (it *IssueTracker) MovedTaskID(taskID string, task issuetracker.Task) string {
+	githubTask, ok := task.(*Task)
+	if !ok || githubTask.HTMLURL == "" {
+		return ""
+	}
+
+	// html urls are in the form https://github.com/owner/repo/issues/number
+	tokens := common.RemoveEmptyTokens(strings.Split(strings.ToLower(githubTask.HTMLURL), "/"))
+	if len(tokens) < 6 {
+		return ""
+	}
+
+	owner, repo := it.repositoryOf(taskID)
+	newOwner, newRepo, number := tokens[2], tokens[3], tokens[5]
+	if newOwner == owner && newRepo == repo {
+		return ""
+	}
+
+	return fmt.Sprintf("%s/%s#%s", newOwner, newRepo, number)
+}
+
+// repositoryOf the given task, which is the origin repository, unless the task references an issue in another one
+func (it *IssueTracker) repositoryOf(taskID string) (owner, repo string) {
+	crossRepo, _ := config.SplitCrossRepoRef(taskID)
+	if crossRepo == "" {
+		_, owner, repo = it.urlTokensFromOrigin()
+		return
+	}
+
+	owner, repo, _ = strings.Cut(strings.ToLower(crossRepo), "/")
+	return
+}
+
+// repositoryAliases returns the repository field of the batch query, each of the given tasks is looked up in,
+// as well as the repository of each aliased field in the form owner/repo.
+// Issues in the origin repository are looked up in the unaliased repository field
+func (it *IssueTracker) repositoryAliases(taskIDs []string) (aliases []string, repos map[string]string) {
+	repos = map[string]string{}
+	aliasOf := map[string]string{"": originRepositoryField}
+	for _, taskID := range taskIDs {
+		crossRepo, _ := config.SplitCrossRepoRef(taskID)
+		crossRepo = strings.ToLower(crossRepo)
+		if _, ok := aliasOf[crossRepo]; !ok {
+			aliasOf[crossRepo] = fmt.Sprintf("r%d", len(repos))
+			repos[aliasOf[crossRepo]] = crossRepo
+		}
+
+		aliases = append(aliases, aliasOf[crossRepo])
+	}
+
+	return aliases, repos
+}
+
 // taskURLFrom taskID returns the url for the target github task ID to fetch
 func (it *IssueTracker) taskURLFrom(taskID string) string {
 	if strings.HasPrefix(taskID, "#") {
@@ -1,8 +1,13 @@
 package github
 
 import (
+	"encoding/json"
 	"fmt"
+	"reflect"
 	"testing"
+
+	"github.com/preslavmihaylov/todocheck/config"
+	"github.com/preslavmihaylov/todocheck/issuetracker/taskstatus"
 )
 
 func Test_IssueTracker_IssueURLFor(t *testing.T) {
@@ -18,6 +23,7 @@ func Test_IssueTracker_IssueURLFor(t *testing.T) {
 		{"github.com/user2020/hyphen-1/", "8", "https://api.github.com/repos/user2020/hyphen-1/issues/8"},
 		{"github.com/user2020/hyphen-1_underscore/", "8", "https://api.github.com/repos/user2020/hyphen-1_underscore/issues/8"},
 		{"GITHUB.com/u1u/hyphen-1_underscore_x-2/", "8", "https://api.github.com/repos/u1u/hyphen-1_underscore_x-2/issues/8"},
+		{"github.com/user/repo", "Org/lib.B#77", "https://api.github.com/repos/org/lib.b/issues/77"},
 	}
 
 	for _, tt := range tests {
@@ -35,3 +41,176 @@ func Test_IssueTracker_IssueURLFor(t *testing.T) {
 	}
 
 }
+
+func Test_IssueTracker_MaxBatchSize(t *testing.T) {
+	var tests = []struct {
+		authCfg *config.Auth
+		want    int
+	}{
+		{nil, 0},
+		{&config.Auth{Type: config.AuthTypeNone}, 0},
+		{&config.Auth{Type: config.AuthTypeAPIToken, Token: "token"}, maxBatchSize},
+	}
+
+	for _, tt := range tests {
+		it := IssueTracker{Origin: "github.com/user/repo", AuthCfg: tt.authCfg}
+		if res := it.MaxBatchSize(); res != tt.want {
+			t.Errorf("got %d, want %d", res, tt.want)
+		}
+	}
+}
+
+func Test_IssueTracker_BatchRequestFor(t *testing.T) {
+	it := IssueTracker{Origin: "github.com/User/Repo"}
+	req, err := it.BatchRequestFor([]string{"#1", "22"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if req.Method != "POST" || req.URL.String() != "https://api.github.com/graphql" {
+		t.Errorf("got %s %s, want POST https://api.github.com/graphql", req.Method, req.URL)
+	}
+
+	var body struct {
+		Query string `json:"query"`
+	}
+	if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+		t.Fatalf("Couldn't decode request body: %v", err)
+	}
+
+	assignees := "assignees(first: 10) { nodes { login } }"
+	want := `query { repository(owner: "user", name: "repo") { ` +
+		`i0: issueOrPullRequest(number: 1) { ... on Issue { title state stateReason ` + assignees + ` } ... on PullRequest { title state ` + assignees + ` } } ` +
+		`i1: issueOrPullRequest(number: 22) { ... on Issue { title state stateReason ` + assignees + ` } ... on PullRequest { title state ` + assignees + ` } } } }`
+	if body.Query != want {
+		t.Errorf("got query %s, want %s", body.Query, want)
+	}
+
+	if _, err := it.BatchRequestFor([]string{"1", "abc"}); err == nil {
+		t.Errorf("Expected error for non-numeric issue")
+	}
+}
+
+func Test_IssueTracker_BatchRequestForOtherRepositories(t *testing.T) {
+	it := IssueTracker{Origin: "github.com/user/repo"}
+	req, err := it.BatchRequestFor([]string{"org/lib#1", "#2", "Org/Lib#3", "org/other#4"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	var body struct {
+		Query string `json:"query"`
+	}
+	if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+		t.Fatalf("Couldn't decode request body: %v", err)
+	}
+
+	issue := func(alias string, number int) string {
+		assignees := "assignees(first: 10) { nodes { login } }"
+		return fmt.Sprintf("%s: issueOrPullRequest(number: %d) { ... on Issue { title state stateReason %[3]s } ... on PullRequest { title state %[3]s } } ", alias, number, assignees)
+	}
+
+	want := `query { r0: repository(owner: "org", name: "lib") { ` + issue("i0", 1) + issue("i2", 3) + `} ` +
+		`repository(owner: "user", name: "repo") { ` + issue("i1", 2) + `} ` +
+		`r1: repository(owner: "org", name: "other") { ` + issue("i3", 4) + `} }`
+	if body.Query != want {
+		t.Errorf("got query %s, want %s", body.Query, want)
+	}
+}
+
+func Test_IssueTracker_TasksFromBatchResponseForOtherRepositories(t *testing.T) {
+	body := []byte(`{
+		"data": {"r0": {"i0": {"state": "CLOSED", "stateReason": "COMPLETED"}}, "repository": {"i1": {"state": "OPEN"}}, "r1": null},
+		"errors": [{"type": "NOT_FOUND", "path": ["r1"]}]
+	}`)
+
+	var it IssueTracker
+	tasks, err := it.TasksFromBatchResponse([]string{"org/lib#1", "#2", "org/missing#3"}, body)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	want := map[string]taskstatus.TaskStatus{
+		"org/lib#1": taskstatus.Closed,
+		"#2":        taskstatus.Open,
+	}
+	if len(tasks) != len(want) {
+		t.Errorf("got %d tasks, want %d", len(tasks), len(want))
+	}
+
+	for taskID, wantStatus := range want {
+		status, _ := tasks[taskID].GetStatus()
+		if status != wantStatus {
+			t.Errorf("got status %v for task %s, want %v", status, taskID, wantStatus)
+		}
+	}
+}
+
+func Test_IssueTracker_TasksFromBatchResponse(t *testing.T) {
+	body := []byte(`{
+		"data": {"repository": {
+			"i0": {"title": "Fix the build", "state": "OPEN", "assignees": {"nodes": [{"login": "alice"}, {"login": "bob"}]}}, "i1": {"state": "CLOSED", "stateReason": "COMPLETED"}, "i2": {"state": "MERGED"}, "i3": null,
+			"i4": {"state": "CLOSED", "stateReason": "NOT_PLANNED"}, "i5": {"state": "CLOSED", "stateReason": "DUPLICATE"}
+		}},
+		"errors": [{"type": "NOT_FOUND", "path": ["repository", "i3"]}]
+	}`)
+
+	var it IssueTracker
+	tasks, err := it.TasksFromBatchResponse([]string{"1", "#2", "3", "4", "5", "6"}, body)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	want := map[string]taskstatus.TaskStatus{
+		"1":  taskstatus.Open,
+		"#2": taskstatus.Closed,
+		"3":  taskstatus.Closed,
+		"5":  taskstatus.ClosedNotPlanned,
+		"6":  taskstatus.ClosedDuplicate,
+	}
+	if len(tasks) != len(want) {
+		t.Errorf("got %d tasks, want %d", len(tasks), len(want))
+	}
+
+	for taskID, wantStatus := range want {
+		status, _ := tasks[taskID].GetStatus()
+		if status != wantStatus {
+			t.Errorf("got status %v for task %s, want %v", status, taskID, wantStatus)
+		}
+	}
+
+	if assignees := tasks["1"].(*Task).Assignees(); !reflect.DeepEqual(assignees, []string{"alice", "bob"}) {
+		t.Errorf("got assignees %v for task 1, want [alice bob]", assignees)
+	}
+
+	if title := tasks["1"].(*Task).Title(); title != "Fix the build" {
+		t.Errorf("got title %q for task 1, want %q", title, "Fix the build")
+	}
+
+	if _, err := it.TasksFromBatchResponse([]string{"1"}, []byte(`{"data": {"repository": null}}`)); err == nil {
+		t.Errorf("Expected error for missing repository")
+	}
+}
+
+func Test_IssueTracker_MovedTaskID(t *testing.T) {
+	tests := []struct {
+		htmlURL string
+		want    string
+	}{
+		{"https://github.com/User/Repo/issues/1", ""},
+		{"https://github.com/user/other-repo/issues/7", "user/other-repo#7"},
+		{"https://github.com/other-user/repo/issues/12", "other-user/repo#12"},
+		{"", ""},
+	}
+
+	it := IssueTracker{Origin: "github.com/user/repo"}
+	for _, tt := range tests {
+		if res := it.MovedTaskID("1", &Task{State: "open", HTMLURL: tt.htmlURL}); res != tt.want {
+			t.Errorf("got moved task %q for html url %q, want %q", res, tt.htmlURL, tt.want)
+		}
+	}
+
+	if res := it.MovedTaskID("Other-User/Repo#12", &Task{State: "open", HTMLURL: "https://github.com/other-user/repo/issues/12"}); res != "" {
+		t.Errorf("got moved task %q for issue in another repository, which wasn't moved", res)
+	}
+}
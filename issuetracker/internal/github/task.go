@@ -4,15 +4,72 @@ import "github.com/preslavmihaylov/todocheck/issuetracker/taskstatus"
 
 // Task model
 type Task struct {
-	State string `json:"state"`
+	State       string `json:"state"`
+	StateReason string `json:"state_reason"`
+	HTMLURL     string `json:"html_url"`
+	AssignedTo  []user `json:"assignees"`
+	IssueTitle  string `json:"title"`
 }
 
-// GetStatus of github task, based on underlying structure
+// user JSON model as returned by the Github API
+type user struct {
+	Login string `json:"login"`
+}
+
+// GetStatus of github task, based on underlying structure.
+// The reason a closed task was closed for is derived from its state reason
 func (t *Task) GetStatus() (taskstatus.TaskStatus, error) {
 	switch t.State {
 	case "closed":
-		return taskstatus.Closed, nil
+		return t.closedStatus(), nil
 	default:
 		return taskstatus.Open, nil
 	}
 }
+
+func (t *Task) closedStatus() taskstatus.TaskStatus {
+	switch t.StateReason {
+	case "not_planned":
+		return taskstatus.ClosedNotPlanned
+	case "duplicate":
+		return taskstatus.ClosedDuplicate
+	default:
+		return taskstatus.Closed
+	}
+}
+
+// RawStatuses of github task, which is its state
+func (t *Task) RawStatuses() []string {
+	return []string{t.State}
+}
+
+// Assignees of github task, which are the logins of its assigned users
+func (t *Task) Assignees() []string {
+	var assignees []string
+	for _, assignee := range t.AssignedTo {
+		assignees = append(assignees, assignee.Login)
+	}
+
+	return assignees
+}
+
+// Title of github task
+func (t *Task) Title() string {
+	return t.IssueTitle
+}
+
+// graphqlResult JSON model as returned by the Github GraphQL API for a batch issue lookup.
+// Repositories & the issues in them are keyed by their alias in the query. Repositories & issues which don't exist are null
+type graphqlResult struct {
+	Data map[string]map[string]*graphqlIssue `json:"data"`
+}
+
+// graphqlIssue JSON model of an issue or pull request in a GraphQL query response
+type graphqlIssue struct {
+	Title       string `json:"title"`
+	State       string `json:"state"`
+	StateReason string `json:"stateReason"`
+	Assignees   struct {
+		Nodes []user `json:"nodes"`
+	} `json:"assignees"`
+}
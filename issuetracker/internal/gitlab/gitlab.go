@@ -1,9 +1,11 @@
 package gitlab
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
 
 	"github.com/preslavmihaylov/todocheck/common"
@@ -11,6 +13,8 @@ import (
 	"github.com/preslavmihaylov/todocheck/issuetracker"
 )
 
+const maxBatchSize = 100
+
 // New creates a new gitlab issuetracker instance
 func New(origin string, authCfg *config.Auth) (*IssueTracker, error) {
 	return &IssueTracker{origin, authCfg}, nil
@@ -27,9 +31,11 @@ func (it *IssueTracker) TaskModel() issuetracker.Task {
 	return &Task{}
 }
 
-// IssueURLFor Returns the full URL for the gitlab issue
+// IssueURLFor Returns the full URL for the gitlab issue.
+// Issues in other projects, referenced via group/project#iid, are looked up in the given project
 func (it *IssueTracker) IssueURLFor(taskID string) string {
-	return it.issueAPIOrigin() + it.taskURLFrom(taskID)
+	project, taskID := config.SplitCrossRepoRef(taskID)
+	return it.issueAPIOrigin(project) + it.taskURLFrom(taskID)
 }
 
 // Exists verifies if the issue tracker exists based on the provided configuration
@@ -62,6 +68,107 @@ func (it *IssueTracker) TokenAcquisitionInstructions() string {
 		"create a read-only access token & paste it here.", extractBaseURL(it.Origin))
 }
 
+// MaxBatchSize returns the max amount of issues looked up in a single request
+func (it *IssueTracker) MaxBatchSize() int {
+	return maxBatchSize
+}
+
+// BatchRequestFor returns a request listing all of the given issues by their project-level iids.
+// As issues can only be listed per project, only the issues in the project of the first task are requested.
+// The rest are missing from the response & are fetched one by one
+func (it *IssueTracker) BatchRequestFor(taskIDs []string) (*http.Request, error) {
+	project := batchProject(taskIDs)
+
+	query := url.Values{}
+	for _, taskID := range taskIDs {
+		if taskProject, ref := config.SplitCrossRepoRef(taskID); taskProject == project {
+			query.Add("iids[]", it.taskURLFrom(ref))
+		}
+	}
+
+	query.Set("per_page", strconv.Itoa(maxBatchSize))
+	issuesURL := strings.TrimSuffix(it.issueAPIOrigin(project), "/")
+	return http.NewRequest("GET", fmt.Sprintf("%s?%s", issuesURL, query.Encode()), nil)
+}
+
+// TasksFromBatchResponse extracts the found issues from an issue list response, keyed by the given task IDs
+func (it *IssueTracker) TasksFromBatchResponse(taskIDs []string, body []byte) (map[string]issuetracker.Task, error) {
+	var issues []Task
+
+	if err := json.Unmarshal(body, &issues); err != nil {
+		return nil, fmt.Errorf("couldn't unmarshal issues JSON: %w", err)
+	}
+
+	found := map[string]*Task{}
+	for i := range issues {
+		found[strconv.Itoa(issues[i].IID)] = &issues[i]
+	}
+
+	project := batchProject(taskIDs)
+	tasks := map[string]issuetracker.Task{}
+	for _, taskID := range taskIDs {
+		taskProject, ref := config.SplitCrossRepoRef(taskID)
+		if taskProject != project {
+			continue
+		}
+
+		if task, ok := found[it.taskURLFrom(ref)]; ok {
+			tasks[taskID] = task
+		}
+	}
+
+	return tasks, nil
+}
+
+// LinkedTaskRequestFor returns a request for the notes of a moved task, newest first.
+// The issue it was moved to is looked up via the system note, which gitlab adds upon moving it, as looking up an issue by its
+// global ID is only allowed for administrators. Nil is returned for tasks, which were not moved
+func (it *IssueTracker) LinkedTaskRequestFor(task issuetracker.Task) (*http.Request, error) {
+	t, ok := task.(*Task)
+	if !ok || t.State != "closed" || t.MovedToID == nil || t.ProjectID == 0 || t.IID == 0 {
+		return nil, nil
+	}
+
+	query := url.Values{}
+	query.Set("sort", "desc")
+	query.Set("order_by", "created_at")
+	query.Set("per_page", strconv.Itoa(maxBatchSize))
+	return http.NewRequest("GET", fmt.Sprintf("%s/projects/%d/issues/%d/notes?%s", it.apiOrigin(), t.ProjectID, t.IID, query.Encode()), nil)
+}
+
+// LinkedTaskIDFrom the notes of a moved task, which is the reference in its "moved to" system note, e.g. group/project#12.
+// If there's no such note among the task's latest notes, an empty string is returned
+func (it *IssueTracker) LinkedTaskIDFrom(body []byte) (string, error) {
+	var notes []struct {
+		Body   string `json:"body"`
+		System bool   `json:"system"`
+	}
+
+	if err := json.Unmarshal(body, &notes); err != nil {
+		return "", fmt.Errorf("couldn't unmarshal notes JSON: %w", err)
+	}
+
+	for _, note := range notes {
+		fields := strings.Fields(note.Body)
+		if note.System && len(fields) == 3 && strings.EqualFold(fields[0], "moved") && fields[1] == "to" {
+			return fields[2], nil
+		}
+	}
+
+	return "", nil
+}
+
+// batchProject returns the project, whose issues are looked up in a batch request for the given tasks.
+// An empty project stands for the origin project
+func batchProject(taskIDs []string) string {
+	if len(taskIDs) == 0 {
+		return ""
+	}
+
+	project, _ := config.SplitCrossRepoRef(taskIDs[0])
+	return project
+}
+
 // TaskURLFrom taskID returns the url for the target gitlab task ID to fetch
 func (it *IssueTracker) taskURLFrom(taskID string) string {
 	if strings.HasPrefix(taskID, "#") {
@@ -71,16 +178,32 @@ func (it *IssueTracker) taskURLFrom(taskID string) string {
 	return taskID
 }
 
-// IssueAPIOrigin returns the URL for github's issue-fetching API
-func (it *IssueTracker) issueAPIOrigin() string {
+// IssueAPIOrigin returns the URL for gitlab's issue-fetching API for the given project.
+// If the project is empty, the origin's project is used
+func (it *IssueTracker) issueAPIOrigin(project string) string {
+	repositoryPath := strings.Join(it.originTokens()[2:], "/")
+	if project != "" {
+		repositoryPath = strings.ToLower(project)
+	}
+
+	urlEncodedProject := url.QueryEscape(repositoryPath)
+	return fmt.Sprintf("%s/projects/%s/issues/", it.apiOrigin(), urlEncodedProject)
+}
+
+// apiOrigin returns the URL of gitlab's API
+func (it *IssueTracker) apiOrigin() string {
+	tokens := it.originTokens()
+	return fmt.Sprintf("%s//%s/api/v4", tokens[0], tokens[1])
+}
+
+// originTokens returns the scheme, host & project path tokens of the origin. The scheme defaults to https
+func (it *IssueTracker) originTokens() []string {
 	tokens := common.RemoveEmptyTokens(strings.Split(strings.ToLower(it.Origin), "/"))
 	if !strings.HasPrefix(tokens[0], "http:") && !strings.HasPrefix(tokens[0], "https:") {
 		tokens = append([]string{"https:"}, tokens...)
 	}
 
-	scheme, host, repositoryPath := tokens[0], tokens[1], tokens[2:]
-	urlEncodedProject := url.QueryEscape(strings.Join(repositoryPath, "/"))
-	return fmt.Sprintf("%s//%s/api/v4/projects/%s/issues/", scheme, host, urlEncodedProject)
+	return tokens
 }
 
 func extractBaseURL(origin string) string {
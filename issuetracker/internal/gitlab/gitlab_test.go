@@ -3,6 +3,8 @@ package gitlab
 import (
 	"fmt"
 	"testing"
+
+	"github.com/preslavmihaylov/todocheck/issuetracker/taskstatus"
 )
 
 func Test_IssueTracker_IssueURLFor(t *testing.T) {
@@ -22,6 +24,7 @@ func Test_IssueTracker_IssueURLFor(t *testing.T) {
 		{"https://gitlab.myORG.com/u/project9-1_2-3-4", "2020", "https://gitlab.myorg.com/api/v4/projects/u%2Fproject9-1_2-3-4/issues/2020"},
 		{"myorg.com/PRESLAVmihaylov/project", "20201225", "https://myorg.com/api/v4/projects/preslavmihaylov%2Fproject/issues/20201225"},
 		{"myorg.co.uk/PreslavMihaylov/project", "20201226", "https://myorg.co.uk/api/v4/projects/preslavmihaylov%2Fproject/issues/20201226"},
+		{"gitlab.com/user/project", "Group/sub-group/lib#77", "https://gitlab.com/api/v4/projects/group%2Fsub-group%2Flib/issues/77"},
 	}
 
 	for _, tt := range tests {
@@ -39,3 +42,122 @@ func Test_IssueTracker_IssueURLFor(t *testing.T) {
 	}
 
 }
+
+func Test_IssueTracker_BatchRequestFor(t *testing.T) {
+	it := IssueTracker{Origin: "gitlab.com/user/project"}
+	req, err := it.BatchRequestFor([]string{"#1", "22"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	want := "https://gitlab.com/api/v4/projects/user%2Fproject/issues?iids%5B%5D=1&iids%5B%5D=22&per_page=100"
+	if req.URL.String() != want {
+		t.Errorf("got %s, want %s", req.URL, want)
+	}
+}
+
+func Test_IssueTracker_BatchRequestForOtherProjects(t *testing.T) {
+	it := IssueTracker{Origin: "gitlab.com/user/project"}
+	req, err := it.BatchRequestFor([]string{"group/lib#1", "#2", "group/lib#3", "group/other#4"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	want := "https://gitlab.com/api/v4/projects/group%2Flib/issues?iids%5B%5D=1&iids%5B%5D=3&per_page=100"
+	if req.URL.String() != want {
+		t.Errorf("got %s, want %s", req.URL, want)
+	}
+
+	body := []byte(`[{"iid": 1, "state": "opened"}, {"iid": 3, "state": "closed"}]`)
+	tasks, err := it.TasksFromBatchResponse([]string{"group/lib#1", "#2", "group/lib#3", "group/other#3"}, body)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(tasks) != 2 || tasks["group/lib#1"] == nil || tasks["group/lib#3"] == nil {
+		t.Errorf("got tasks %v, want only the ones in group/lib", tasks)
+	}
+}
+
+func Test_IssueTracker_TasksFromBatchResponse(t *testing.T) {
+	body := []byte(`[
+		{"iid": 1, "state": "opened"}, {"iid": 22, "state": "closed"},
+		{"iid": 4, "state": "closed", "moved_to_id": 1234},
+		{"iid": 5, "state": "closed", "_links": {"closed_as_duplicate_of": "https://gitlab.com/api/v4/projects/1/issues/40"}}
+	]`)
+
+	var it IssueTracker
+	tasks, err := it.TasksFromBatchResponse([]string{"#1", "22", "3", "4", "5"}, body)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	want := map[string]taskstatus.TaskStatus{
+		"#1": taskstatus.Open,
+		"22": taskstatus.Closed,
+		"4":  taskstatus.Moved,
+		"5":  taskstatus.ClosedDuplicate,
+	}
+	if len(tasks) != len(want) {
+		t.Errorf("got %d tasks, want %d", len(tasks), len(want))
+	}
+
+	for taskID, wantStatus := range want {
+		status, _ := tasks[taskID].GetStatus()
+		if status != wantStatus {
+			t.Errorf("got status %v for task %s, want %v", status, taskID, wantStatus)
+		}
+	}
+
+	if linkedID := tasks["5"].(*Task).LinkedTaskID(); linkedID != "#40" {
+		t.Errorf("got linked task %q for duplicate task, want #40", linkedID)
+	}
+}
+
+func Test_IssueTracker_LinkedTaskRequestFor(t *testing.T) {
+	it := IssueTracker{Origin: "https://gitlab.example.com/user/project"}
+	movedToID := 1234
+
+	req, err := it.LinkedTaskRequestFor(&Task{IID: 7, ProjectID: 42, State: "closed", MovedToID: &movedToID})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	want := "https://gitlab.example.com/api/v4/projects/42/issues/7/notes?order_by=created_at&per_page=100&sort=desc"
+	if req == nil || req.URL.String() != want {
+		t.Errorf("got request %v, want %s", req, want)
+	}
+
+	req, err = it.LinkedTaskRequestFor(&Task{IID: 7, ProjectID: 42, State: "closed"})
+	if err != nil || req != nil {
+		t.Errorf("got request %v & error %v for task, which wasn't moved, want neither", req, err)
+	}
+}
+
+func Test_IssueTracker_LinkedTaskIDFrom(t *testing.T) {
+	var it IssueTracker
+	tests := []struct {
+		body string
+		want string
+	}{
+		{`[{"body": "moved to group/lib#12", "system": true}, {"body": "changed the description", "system": true}]`, "group/lib#12"},
+		{`[{"body": "Moved to group/lib#12", "system": true}]`, "group/lib#12"},
+		{`[{"body": "moved to group/lib#12", "system": false}, {"body": "closed", "system": true}]`, ""},
+		{`[]`, ""},
+	}
+
+	for _, tt := range tests {
+		linkedID, err := it.LinkedTaskIDFrom([]byte(tt.body))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if linkedID != tt.want {
+			t.Errorf("got linked task %q for notes %s, want %q", linkedID, tt.body, tt.want)
+		}
+	}
+
+	if _, err := it.LinkedTaskIDFrom([]byte(`{"message": "403 Forbidden"}`)); err == nil {
+		t.Errorf("got no error for a response, which isn't a list of notes")
+	}
+}
@@ -1,18 +1,69 @@
 package gitlab
 
-import "github.com/preslavmihaylov/todocheck/issuetracker/taskstatus"
+import (
+	"path"
+
+	"github.com/preslavmihaylov/todocheck/issuetracker/taskstatus"
+)
 
 // Task model for gitlab tasks
 type Task struct {
-	State string `json:"state"`
+	IID        int    `json:"iid"`
+	ProjectID  int    `json:"project_id"`
+	State      string `json:"state"`
+	IssueTitle string `json:"title"`
+	MovedToID  *int   `json:"moved_to_id"`
+	AssignedTo []struct {
+		Username string `json:"username"`
+	} `json:"assignees"`
+	Links struct {
+		ClosedAsDuplicateOf string `json:"closed_as_duplicate_of"`
+	} `json:"_links"`
 }
 
-// GetStatus of gitlab task, based on underlying structure
+// GetStatus of gitlab task, based on underlying structure.
+// Closed tasks are moved, if they were moved to another project, or duplicates, if they link to the task they duplicate
 func (t *Task) GetStatus() (taskstatus.TaskStatus, error) {
 	switch t.State {
 	case "closed":
+		if t.MovedToID != nil {
+			return taskstatus.Moved, nil
+		} else if t.Links.ClosedAsDuplicateOf != "" {
+			return taskstatus.ClosedDuplicate, nil
+		}
+
 		return taskstatus.Closed, nil
 	default:
 		return taskstatus.Open, nil
 	}
 }
+
+// RawStatuses of gitlab task, which is its state
+func (t *Task) RawStatuses() []string {
+	return []string{t.State}
+}
+
+// Assignees of gitlab task, which are the usernames of its assigned users
+func (t *Task) Assignees() []string {
+	var assignees []string
+	for _, assignee := range t.AssignedTo {
+		assignees = append(assignees, assignee.Username)
+	}
+
+	return assignees
+}
+
+// Title of gitlab task
+func (t *Task) Title() string {
+	return t.IssueTitle
+}
+
+// LinkedTaskID returns the reference of the issue, a duplicate task was closed in favor of.
+// It is extracted from the issue's API URL, which ends with the issue's iid. The issue, a task was moved to, is looked up separately
+func (t *Task) LinkedTaskID() string {
+	if status, _ := t.GetStatus(); status != taskstatus.ClosedDuplicate {
+		return ""
+	}
+
+	return "#" + path.Base(t.Links.ClosedAsDuplicateOf)
+}
@@ -1,34 +1,50 @@
 package jira
 
 import (
+	"context"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/preslavmihaylov/todocheck/common"
 	"github.com/preslavmihaylov/todocheck/config"
 	"github.com/preslavmihaylov/todocheck/issuetracker"
 )
 
-const defaultJIRAVersion = 9
+const (
+	defaultJIRAVersion = 9
+	maxBatchSize       = 50
+
+	// serverInfoTimeout bounds the server version lookup, as an unresponsive server shouldn't block todocheck
+	serverInfoTimeout = 10 * time.Second
+)
 
 // New creates a new jira issuetracker instance
-func New(origin string, authCfg *config.Auth) (*IssueTracker, error) {
+func New(ctx context.Context, origin string, authCfg *config.Auth) (*IssueTracker, error) {
 	return &IssueTracker{
 		Origin:        origin,
 		AuthCfg:       authCfg,
-		serverVersion: deriveJIRAServerVersion(origin),
+		serverVersion: deriveJIRAServerVersion(ctx, origin),
 	}, nil
 }
 
-func deriveJIRAServerVersion(origin string) int {
-	resp, err := http.Get(origin + "/rest/api/2/serverInfo")
+func deriveJIRAServerVersion(ctx context.Context, origin string) int {
+	req, err := http.NewRequestWithContext(ctx, "GET", origin+"/rest/api/2/serverInfo", nil)
+	if err != nil {
+		return defaultJIRAVersion
+	}
+
+	client := &http.Client{Timeout: serverInfoTimeout}
+	resp, err := client.Do(req)
 	if err != nil {
 		return defaultJIRAVersion
 	}
+	defer resp.Body.Close()
 
 	type serverInfo struct {
 		Version string `json:"version"`
@@ -112,6 +128,68 @@ func (it *IssueTracker) TokenAcquisitionInstructions() string {
 	}
 }
 
+// MaxBatchSize returns the max amount of issues looked up in a single JQL search
+func (it *IssueTracker) MaxBatchSize() int {
+	return maxBatchSize
+}
+
+// BatchRequestFor returns a JQL search request for all of the given issues.
+// Query validation is relaxed, so that non-existent issues don't fail the whole search
+func (it *IssueTracker) BatchRequestFor(taskIDs []string) (*http.Request, error) {
+	keys := make([]string, len(taskIDs))
+	for i, taskID := range taskIDs {
+		keys[i] = strconv.Quote(it.taskURLFrom(taskID))
+	}
+
+	query := url.Values{}
+	query.Set("jql", fmt.Sprintf("key in (%s)", strings.Join(keys, ",")))
+	query.Set("fields", "summary,status,resolution,issuelinks,assignee")
+	query.Set("maxResults", strconv.Itoa(len(taskIDs)))
+	query.Set("validateQuery", "warn")
+
+	return http.NewRequest("GET", fmt.Sprintf("%s/rest/api/2/search?%s", it.Origin, query.Encode()), nil)
+}
+
+// TasksFromBatchResponse extracts the found issues from a JQL search response, keyed by the given task IDs
+func (it *IssueTracker) TasksFromBatchResponse(taskIDs []string, body []byte) (map[string]issuetracker.Task, error) {
+	var res searchResult
+	if err := json.Unmarshal(body, &res); err != nil {
+		return nil, fmt.Errorf("couldn't unmarshal search result JSON: %w", err)
+	}
+
+	found := map[string]*Task{}
+	for i := range res.Issues {
+		issue := &res.Issues[i]
+		found[issue.ID] = issue
+		found[strings.ToUpper(issue.Key)] = issue
+	}
+
+	tasks := map[string]issuetracker.Task{}
+	for _, taskID := range taskIDs {
+		if task, ok := found[strings.ToUpper(it.taskURLFrom(taskID))]; ok {
+			tasks[taskID] = task
+		}
+	}
+
+	return tasks, nil
+}
+
+// MovedTaskID returns the new key of the fetched task, if it was moved to another project.
+// Jira returns moved issues, when they are looked up by their old key, hence the key differs from the requested one
+func (it *IssueTracker) MovedTaskID(taskID string, task issuetracker.Task) string {
+	jiraTask, ok := task.(*Task)
+	if !ok || jiraTask.Key == "" {
+		return ""
+	}
+
+	ref := it.taskURLFrom(taskID)
+	if ref == jiraTask.ID || strings.EqualFold(ref, jiraTask.Key) {
+		return ""
+	}
+
+	return jiraTask.Key
+}
+
 // TaskURLFrom taskID returns the url for the target Jira task ID to fetch
 func (it *IssueTracker) taskURLFrom(taskID string) string {
 	return taskID
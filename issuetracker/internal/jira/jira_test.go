@@ -0,0 +1,148 @@
+package jira
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/preslavmihaylov/todocheck/issuetracker/taskstatus"
+)
+
+func Test_IssueTracker_BatchRequestFor(t *testing.T) {
+	it := IssueTracker{Origin: "https://jira.myorg.com"}
+	req, err := it.BatchRequestFor([]string{"ABC-1", "ABC-2"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if req.URL.Path != "/rest/api/2/search" {
+		t.Errorf("got path %s, want /rest/api/2/search", req.URL.Path)
+	}
+
+	query := req.URL.Query()
+	want := map[string]string{
+		"jql":           `key in ("ABC-1","ABC-2")`,
+		"fields":        "summary,status,resolution,issuelinks,assignee",
+		"maxResults":    "2",
+		"validateQuery": "warn",
+	}
+	for param, value := range want {
+		if query.Get(param) != value {
+			t.Errorf("got %s=%q, want %q", param, query.Get(param), value)
+		}
+	}
+}
+
+func Test_IssueTracker_TasksFromBatchResponse(t *testing.T) {
+	body := []byte(`{"issues": [
+		{"id": "10001", "key": "ABC-1", "fields": {"status": {"statusCategory": {"name": "Done"}}}},
+		{"id": "10002", "key": "ABC-2", "fields": {"status": {"statusCategory": {"name": "In Progress"}}}}
+	]}`)
+
+	var it IssueTracker
+	tasks, err := it.TasksFromBatchResponse([]string{"ABC-1", "abc-2", "ABC-3", "10001"}, body)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	want := map[string]taskstatus.TaskStatus{
+		"ABC-1": taskstatus.Closed,
+		"abc-2": taskstatus.Open,
+		"10001": taskstatus.Closed,
+	}
+	if len(tasks) != len(want) {
+		t.Errorf("got %d tasks, want %d", len(tasks), len(want))
+	}
+
+	for taskID, wantStatus := range want {
+		task, ok := tasks[taskID]
+		if !ok {
+			t.Errorf("task %s not found", taskID)
+			continue
+		}
+
+		status, _ := task.GetStatus()
+		if status != wantStatus {
+			t.Errorf("got status %v for task %s, want %v", status, taskID, wantStatus)
+		}
+	}
+}
+
+func Test_Task_GetStatus(t *testing.T) {
+	tests := []struct {
+		name         string
+		body         string
+		want         taskstatus.TaskStatus
+		wantLinkedID string
+	}{
+		{
+			name: "open",
+			body: `{"fields": {"status": {"name": "In Progress", "statusCategory": {"name": "In Progress"}}}}`,
+			want: taskstatus.Open,
+		},
+		{
+			name: "done",
+			body: `{"fields": {"status": {"name": "Done", "statusCategory": {"name": "Done"}}, "resolution": {"name": "Done"}}}`,
+			want: taskstatus.Closed,
+		},
+		{
+			name: "won't do",
+			body: `{"fields": {"status": {"name": "Done", "statusCategory": {"name": "Done"}}, "resolution": {"name": "Won't Do"}}}`,
+			want: taskstatus.ClosedNotPlanned,
+		},
+		{
+			name: "duplicate",
+			body: `{"fields": {"status": {"name": "Done", "statusCategory": {"name": "Done"}}, "resolution": {"name": "Duplicate"}, "issuelinks": [
+				{"type": {"name": "Blocks"}, "outwardIssue": {"key": "ABC-2"}},
+				{"type": {"name": "Duplicate"}, "inwardIssue": {"key": "ABC-3"}},
+				{"type": {"name": "Duplicate"}, "outwardIssue": {"key": "ABC-4"}}
+			]}}`,
+			want:         taskstatus.ClosedDuplicate,
+			wantLinkedID: "ABC-4",
+		},
+		{
+			name: "duplicate without link",
+			body: `{"fields": {"status": {"name": "Done", "statusCategory": {"name": "Done"}}, "resolution": {"name": "Duplicate"}}}`,
+			want: taskstatus.ClosedDuplicate,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var task Task
+			if err := json.Unmarshal([]byte(tt.body), &task); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			status, err := task.GetStatus()
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if status != tt.want {
+				t.Errorf("got status %v, want %v", status, tt.want)
+			}
+			if task.LinkedTaskID() != tt.wantLinkedID {
+				t.Errorf("got linked task %q, want %q", task.LinkedTaskID(), tt.wantLinkedID)
+			}
+		})
+	}
+}
+
+func Test_IssueTracker_MovedTaskID(t *testing.T) {
+	tests := []struct {
+		taskID string
+		task   *Task
+		want   string
+	}{
+		{"ABC-1", &Task{ID: "10001", Key: "ABC-1"}, ""},
+		{"abc-1", &Task{ID: "10001", Key: "ABC-1"}, ""},
+		{"10001", &Task{ID: "10001", Key: "ABC-1"}, ""},
+		{"ABC-1", &Task{ID: "10001", Key: "XYZ-7"}, "XYZ-7"},
+		{"ABC-1", &Task{}, ""},
+	}
+
+	var it IssueTracker
+	for _, tt := range tests {
+		if res := it.MovedTaskID(tt.taskID, tt.task); res != tt.want {
+			t.Errorf("got moved task %q for task %s with key %s, want %q", res, tt.taskID, tt.task.Key, tt.want)
+		}
+	}
+}
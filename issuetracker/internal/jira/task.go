@@ -1,24 +1,115 @@
 package jira
 
-import "github.com/preslavmihaylov/todocheck/issuetracker/taskstatus"
+import (
+	"strings"
+
+	"github.com/preslavmihaylov/todocheck/issuetracker/taskstatus"
+)
+
+// duplicateLinkType is the name of the issue link type Jira uses for linking duplicate issues
+const duplicateLinkType = "Duplicate"
 
 // Task JSON model as returned by the Jira Rest API
 type Task struct {
+	ID     string `json:"id"`
+	Key    string `json:"key"`
 	Fields struct {
-		Status struct {
+		Summary string `json:"summary"`
+		Status  struct {
+			Name           string `json:"name"`
 			StatusCategory struct {
 				Name string `json:"name"`
 			} `json:"statusCategory"`
 		} `json:"status"`
+		Resolution *struct {
+			Name string `json:"name"`
+		} `json:"resolution"`
+		Assignee *struct {
+			Name         string `json:"name"`
+			AccountID    string `json:"accountId"`
+			DisplayName  string `json:"displayName"`
+			EmailAddress string `json:"emailAddress"`
+		} `json:"assignee"`
+		IssueLinks []struct {
+			Type struct {
+				Name string `json:"name"`
+			} `json:"type"`
+			OutwardIssue *struct {
+				Key string `json:"key"`
+			} `json:"outwardIssue"`
+		} `json:"issuelinks"`
 	} `json:"fields"`
 }
 
-// GetStatus of jira task, based on underlying structure
+// GetStatus of jira task, based on underlying structure.
+// The reason a done task was closed for is derived from its resolution
 func (t *Task) GetStatus() (taskstatus.TaskStatus, error) {
 	switch t.Fields.Status.StatusCategory.Name {
 	case "Done":
-		return taskstatus.Closed, nil
+		return t.closedStatus(), nil
 	default:
 		return taskstatus.Open, nil
 	}
 }
+
+// RawStatuses of jira task, which are its status name & status category
+func (t *Task) RawStatuses() []string {
+	return []string{t.Fields.Status.Name, t.Fields.Status.StatusCategory.Name}
+}
+
+// Title of jira task, which is its summary
+func (t *Task) Title() string {
+	return t.Fields.Summary
+}
+
+// Assignees of jira task. Jira issues have a single assignee, which is identified by its username on Jira server,
+// its account ID on Jira cloud, its display name & its email address, if it's visible
+func (t *Task) Assignees() []string {
+	if t.Fields.Assignee == nil {
+		return nil
+	}
+
+	var assignees []string
+	for _, id := range []string{t.Fields.Assignee.Name, t.Fields.Assignee.AccountID, t.Fields.Assignee.DisplayName, t.Fields.Assignee.EmailAddress} {
+		if id != "" {
+			assignees = append(assignees, id)
+		}
+	}
+
+	return assignees
+}
+
+// LinkedTaskID returns the key of the issue, a duplicate task was closed in favor of
+func (t *Task) LinkedTaskID() string {
+	if status, _ := t.GetStatus(); status != taskstatus.ClosedDuplicate {
+		return ""
+	}
+
+	for _, link := range t.Fields.IssueLinks {
+		if link.Type.Name == duplicateLinkType && link.OutwardIssue != nil {
+			return link.OutwardIssue.Key
+		}
+	}
+
+	return ""
+}
+
+func (t *Task) closedStatus() taskstatus.TaskStatus {
+	if t.Fields.Resolution == nil {
+		return taskstatus.Closed
+	}
+
+	switch strings.ToLower(t.Fields.Resolution.Name) {
+	case "duplicate":
+		return taskstatus.ClosedDuplicate
+	case "won't do", "won't fix", "cannot reproduce", "incomplete", "declined":
+		return taskstatus.ClosedNotPlanned
+	default:
+		return taskstatus.Closed
+	}
+}
+
+// searchResult JSON model as returned by the Jira search Rest API
+type searchResult struct {
+	Issues []Task `json:"issues"`
+}
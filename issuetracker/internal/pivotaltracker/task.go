@@ -5,6 +5,7 @@ import "github.com/preslavmihaylov/todocheck/issuetracker/taskstatus"
 // Task model
 type Task struct {
 	CurrentState string `json:"current_state"`
+	Name         string `json:"name"`
 }
 
 // GetStatus of pivotal tracker task, based on underlying structure
@@ -22,3 +23,13 @@ func (t *Task) GetStatus() (taskstatus.TaskStatus, error) {
 		return taskstatus.Open, nil
 	}
 }
+
+// RawStatuses of pivotal tracker task, which is its current state
+func (t *Task) RawStatuses() []string {
+	return []string{t.CurrentState}
+}
+
+// Title of pivotal tracker task, which is its story name
+func (t *Task) Title() string {
+	return t.Name
+}
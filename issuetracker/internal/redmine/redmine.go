@@ -1,8 +1,11 @@
 package redmine
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
+	"strconv"
 	"strings"
 
 	"github.com/preslavmihaylov/todocheck/common"
@@ -10,6 +13,8 @@ import (
 	"github.com/preslavmihaylov/todocheck/issuetracker"
 )
 
+const maxBatchSize = 100
+
 // New creates a new redmine issuetracker instance
 func New(origin string, authCfg *config.Auth) (*IssueTracker, error) {
 	return &IssueTracker{origin, authCfg}, nil
@@ -60,6 +65,63 @@ func (it *IssueTracker) TokenAcquisitionInstructions() string {
 	return fmt.Sprintf("Please go to %s/my/account, create a new API token & paste it here.", it.Origin)
 }
 
+// MaxBatchSize returns the max amount of issues looked up in a single request
+func (it *IssueTracker) MaxBatchSize() int {
+	return maxBatchSize
+}
+
+// BatchRequestFor returns a request listing all of the given issues, regardless of their status
+func (it *IssueTracker) BatchRequestFor(taskIDs []string) (*http.Request, error) {
+	ids := make([]string, len(taskIDs))
+	for i, taskID := range taskIDs {
+		ids[i] = strings.TrimPrefix(taskID, "#")
+	}
+
+	query := url.Values{}
+	query.Set("issue_id", strings.Join(ids, ","))
+	query.Set("status_id", "*")
+	query.Set("limit", strconv.Itoa(maxBatchSize))
+	return http.NewRequest("GET", fmt.Sprintf("%s/issues.json?%s", it.Origin, query.Encode()), nil)
+}
+
+// TasksFromBatchResponse extracts the found issues from an issue list response, keyed by the given task IDs
+func (it *IssueTracker) TasksFromBatchResponse(taskIDs []string, body []byte) (map[string]issuetracker.Task, error) {
+	var res struct {
+		Issues []struct {
+			ID      int    `json:"id"`
+			Subject string `json:"subject"`
+			Status  struct {
+				Name string `json:"name"`
+			} `json:"status"`
+			AssignedTo *struct {
+				Name string `json:"name"`
+			} `json:"assigned_to"`
+		} `json:"issues"`
+	}
+
+	if err := json.Unmarshal(body, &res); err != nil {
+		return nil, fmt.Errorf("couldn't unmarshal issues JSON: %w", err)
+	}
+
+	found := map[string]*Task{}
+	for _, issue := range res.Issues {
+		task := &Task{}
+		task.Issue.Subject = issue.Subject
+		task.Issue.Status.Name = issue.Status.Name
+		task.Issue.AssignedTo = issue.AssignedTo
+		found[strconv.Itoa(issue.ID)] = task
+	}
+
+	tasks := map[string]issuetracker.Task{}
+	for _, taskID := range taskIDs {
+		if task, ok := found[strings.TrimPrefix(taskID, "#")]; ok {
+			tasks[taskID] = task
+		}
+	}
+
+	return tasks, nil
+}
+
 // TaskURLFrom taskID returns the url for the target redmine task ID to fetch
 func (it *IssueTracker) taskURLFrom(taskID string) string {
 	taskID = strings.TrimPrefix(taskID, "#")
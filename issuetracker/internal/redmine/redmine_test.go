@@ -0,0 +1,49 @@
+package redmine
+
+import (
+	"testing"
+
+	"github.com/preslavmihaylov/todocheck/issuetracker/taskstatus"
+)
+
+func Test_IssueTracker_BatchRequestFor(t *testing.T) {
+	it := IssueTracker{Origin: "https://redmine.myorg.com"}
+	req, err := it.BatchRequestFor([]string{"#1", "22"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	want := "https://redmine.myorg.com/issues.json?issue_id=1%2C22&limit=100&status_id=%2A"
+	if req.URL.String() != want {
+		t.Errorf("got %s, want %s", req.URL, want)
+	}
+}
+
+func Test_IssueTracker_TasksFromBatchResponse(t *testing.T) {
+	body := []byte(`{"issues": [{"id": 1, "subject": "Fix login", "status": {"name": "New"}}, {"id": 22, "status": {"name": "Rejected"}}]}`)
+
+	var it IssueTracker
+	tasks, err := it.TasksFromBatchResponse([]string{"#1", "22", "3"}, body)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	want := map[string]taskstatus.TaskStatus{
+		"#1": taskstatus.Open,
+		"22": taskstatus.Closed,
+	}
+	if len(tasks) != len(want) {
+		t.Errorf("got %d tasks, want %d", len(tasks), len(want))
+	}
+
+	for taskID, wantStatus := range want {
+		status, _ := tasks[taskID].GetStatus()
+		if status != wantStatus {
+			t.Errorf("got status %v for task %s, want %v", status, taskID, wantStatus)
+		}
+	}
+
+	if title := tasks["#1"].(*Task).Title(); title != "Fix login" {
+		t.Errorf("got title %q for task #1, want \"Fix login\"", title)
+	}
+}
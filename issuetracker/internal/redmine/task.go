@@ -5,9 +5,13 @@ import "github.com/preslavmihaylov/todocheck/issuetracker/taskstatus"
 // Task model
 type Task struct {
 	Issue struct {
-		Status struct {
+		Subject string `json:"subject"`
+		Status  struct {
 			Name string `json:"name"`
 		} `json:"status"`
+		AssignedTo *struct {
+			Name string `json:"name"`
+		} `json:"assigned_to"`
 	} `json:"issue"`
 }
 
@@ -26,3 +30,22 @@ func (t *Task) GetStatus() (taskstatus.TaskStatus, error) {
 		return taskstatus.Open, nil
 	}
 }
+
+// RawStatuses of redmine task, which is its status name
+func (t *Task) RawStatuses() []string {
+	return []string{t.Issue.Status.Name}
+}
+
+// Title of redmine task, which is its subject
+func (t *Task) Title() string {
+	return t.Issue.Subject
+}
+
+// Assignees of redmine task, which is the name of the user or group it's assigned to
+func (t *Task) Assignees() []string {
+	if t.Issue.AssignedTo == nil {
+		return nil
+	}
+
+	return []string{t.Issue.AssignedTo.Name}
+}
@@ -10,6 +10,7 @@ const (
 	Type       = "$type"
 	StateType  = "StateIssueCustomField"
 	IsResolved = "isResolved"
+	Name       = "name"
 )
 
 type Task struct {
@@ -37,3 +38,20 @@ func (t *Task) GetStatus() (taskstatus.TaskStatus, error) {
 		return taskstatus.Open, nil
 	}
 }
+
+// RawStatuses of youtrack task, which is the name of its state
+func (t *Task) RawStatuses() []string {
+	for _, node := range t.CustomFields {
+		if node[Type] != StateType {
+			continue
+		}
+
+		if value, ok := node[Value].(map[string]interface{}); ok {
+			if name, ok := value[Name].(string); ok {
+				return []string{name}
+			}
+		}
+	}
+
+	return nil
+}
@@ -63,7 +63,7 @@ func (it *IssueTracker) TokenAcquisitionInstructions() string {
 // TaskURLFrom taskID returns the url for the target Youtrack task ID to fetch
 func (it *IssueTracker) taskURLFrom(taskID string) string {
 	taskID = strings.TrimPrefix(taskID, "#")
-	return fmt.Sprintf("%s?fields=customFields(value(isResolved))", taskID)
+	return fmt.Sprintf("%s?fields=customFields(value(name,isResolved))", taskID)
 }
 
 func (it *IssueTracker) urlTokensFromOrigin() (scheme, instance string) {
@@ -12,7 +12,38 @@ var ErrUnsupportedHealthCheck = errors.New("unsupported issue tracker for health
 
 // Task is an interface for generic task operations, decoupled from the specific platform's task structure
 type Task interface {
+	// GetStatus of the task, based on the issue tracker's default status mapping
 	GetStatus() (taskstatus.TaskStatus, error)
+
+	// RawStatuses returns the issue tracker specific names of the task's status, ordered from most to least specific.
+	// E.g. for Jira, these are the status name & the status category
+	RawStatuses() []string
+}
+
+// LinkedTask is implemented by tasks, which can refer to another task based on their status.
+// E.g. a task, closed as a duplicate, refers to the task it duplicates
+type LinkedTask interface {
+	Task
+
+	// LinkedTaskID returns the ID of the referred task or an empty string if there is none
+	LinkedTaskID() string
+}
+
+// AssignedTask is implemented by tasks, which expose the users they are assigned to
+type AssignedTask interface {
+	Task
+
+	// Assignees returns the identifiers of the users, the task is assigned to, e.g. their usernames, names or emails.
+	// An unassigned task has no assignees
+	Assignees() []string
+}
+
+// TitledTask is implemented by tasks, which expose their title
+type TitledTask interface {
+	Task
+
+	// Title of the task, e.g. the issue's summary
+	Title() string
 }
 
 // IssueTracker is an interface, which all issue tracker integration components adhere to in order to
@@ -33,3 +64,42 @@ type IssueTracker interface {
 	// TokenAcquisitionInstructions returns instructions for manually acquiring the authentication token
 	TokenAcquisitionInstructions() string
 }
+
+// MovedIssueTracker is implemented by issue trackers, which can detect that an issue was moved or transferred
+// based on the task fetched for the issue's old ID
+type MovedIssueTracker interface {
+	IssueTracker
+
+	// MovedTaskID returns the new ID of the task, fetched for the given task ID, if it was moved.
+	// If the task wasn't moved, an empty string is returned
+	MovedTaskID(taskID string, task Task) string
+}
+
+// LinkLookupIssueTracker is implemented by issue trackers, whose tasks refer to the task they link to by an ID,
+// which has to be looked up to get the linked task's reference. E.g. a gitlab issue refers to the issue it was moved to by its global ID
+type LinkLookupIssueTracker interface {
+	IssueTracker
+
+	// LinkedTaskRequestFor returns a request for looking up the task, the given task links to.
+	// If the task doesn't link to a task, which has to be looked up, nil is returned
+	LinkedTaskRequestFor(task Task) (*http.Request, error)
+
+	// LinkedTaskIDFrom extracts the ID of the linked task from the response to its lookup or returns an empty string if it's not present
+	LinkedTaskIDFrom(body []byte) (string, error)
+}
+
+// BatchIssueTracker is implemented by issue trackers, which support looking up multiple issues in a single request
+type BatchIssueTracker interface {
+	IssueTracker
+
+	// MaxBatchSize returns the max amount of issues, which can be looked up in a single request.
+	// Zero means batch lookups are not available with the current configuration
+	MaxBatchSize() int
+
+	// BatchRequestFor returns a request for looking up all of the given issues
+	BatchRequestFor(taskIDs []string) (*http.Request, error)
+
+	// TasksFromBatchResponse extracts the tasks from a batch lookup response, keyed by the task IDs they were requested with.
+	// Issues which don't exist are not present in the result
+	TasksFromBatchResponse(taskIDs []string, body []byte) (map[string]Task, error)
+}
@@ -9,4 +9,36 @@ const (
 	Open
 	Closed
 	NonExistent
+
+	// Other is a status, which is neither open nor closed, as configured via the status mapping
+	Other
+
+	// ClosedNotPlanned is a task, which was closed without being done, e.g. as won't fix
+	ClosedNotPlanned
+
+	// ClosedDuplicate is a task, which was closed as a duplicate of another task
+	ClosedDuplicate
+
+	// Moved is a task, which was moved or transferred to another project
+	Moved
 )
+
+// IsClosed returns true if the task is closed, regardless of the reason it was closed for
+func (s TaskStatus) IsClosed() bool {
+	return s == Closed || s == ClosedNotPlanned || s == ClosedDuplicate
+}
+
+// Result of looking up a task's status
+type Result struct {
+	Status TaskStatus
+
+	// LinkedTaskID is the ID of the task, the looked up task refers to, if known.
+	// E.g. the task a duplicate was closed in favor of or the new ID of a moved task
+	LinkedTaskID string
+
+	// Assignees of the looked up task, if the issue tracker exposes them
+	Assignees []string
+
+	// Title of the looked up task, if the issue tracker exposes it
+	Title string
+}
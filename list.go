@@ -0,0 +1,204 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/bmatcuk/doublestar"
+	"github.com/preslavmihaylov/todocheck/config"
+	"github.com/preslavmihaylov/todocheck/issuetracker/taskstatus"
+	"github.com/preslavmihaylov/todocheck/traverser/todoerrs"
+)
+
+// statusMalformed is the status, malformed todos are filtered by, as they don't reference any issues
+const statusMalformed = "malformed"
+
+// listStatuses of the issues, the list command reports & filters by
+var listStatuses = []string{"open", "closed", "not-planned", "duplicate", "moved", "nonexistent", "other", "unknown", statusMalformed}
+
+// listedTodo is a todo, reported by the list command
+type listedTodo struct {
+	Filename    string         `json:"filename"`
+	Line        int            `json:"line"`
+	Keyword     string         `json:"keyword"`
+	IsMalformed bool           `json:"malformed"`
+	Issues      []*listedIssue `json:"issues"`
+	Comment     string         `json:"comment"`
+}
+
+// listedIssue is an issue, referenced by a listed todo
+type listedIssue struct {
+	ID     string `json:"id"`
+	Status string `json:"status"`
+	Title  string `json:"title,omitempty"`
+	URL    string `json:"url,omitempty"`
+}
+
+// listFilter of the listed todos. Empty fields don't filter
+type listFilter struct {
+	issue, status, keyword, path string
+}
+
+// listTodos found by the traverser's last traversal, which match the given filter. The statuses of their issues are fetched, unless offline
+func listTodos(ctx context.Context, traverser *todoerrs.Traverser, filter *listFilter) []*listedTodo {
+	listed := []*listedTodo{}
+	for _, todo := range traverser.Todos() {
+		if !filter.matchesTodo(todo) {
+			continue
+		}
+
+		lt := &listedTodo{
+			Filename:    todo.Filename,
+			Line:        todo.Line,
+			Keyword:     todo.Keyword,
+			IsMalformed: todo.IsMalformed,
+			Issues:      []*listedIssue{},
+			Comment:     strings.TrimRight(todo.Comment, "\r\n"),
+		}
+
+		for _, taskID := range todo.TaskIDs {
+			issue := &listedIssue{ID: config.DisplayTaskID(taskID), Status: "unknown", URL: traverser.WebURLFor(taskID)}
+			if res, err := traverser.IssueStatus(ctx, taskID); err == nil {
+				issue.Status, issue.Title = statusName(res.Status), res.Title
+			}
+
+			lt.Issues = append(lt.Issues, issue)
+		}
+
+		if filter.matchesStatus(lt) {
+			listed = append(listed, lt)
+		}
+	}
+
+	return listed
+}
+
+// validate the filter's status & path
+func (f *listFilter) validate() error {
+	if f.status != "" && !contains(listStatuses, f.status) {
+		return fmt.Errorf("invalid status %q. valid statuses are: %q", f.status, listStatuses)
+	} else if _, err := doublestar.Match(f.path, ""); err != nil {
+		return fmt.Errorf("invalid path pattern %q: %w", f.path, err)
+	}
+
+	return nil
+}
+
+// matchesTodo checks if the given todo matches the filter's issue, keyword & path. The file matches the path,
+// if it's the path itself, a file in the path's directory or matches the path as a glob, e.g. **/*.py
+func (f *listFilter) matchesTodo(todo *todoerrs.Todo) bool {
+	if f.keyword != "" && !strings.EqualFold(f.keyword, todo.Keyword) {
+		return false
+	}
+
+	if f.path != "" {
+		path := filepath.Clean(f.path)
+		isMatch, _ := doublestar.Match(path, todo.Filename)
+		if !isMatch && todo.Filename != path && !strings.HasPrefix(todo.Filename, path+string(filepath.Separator)) {
+			return false
+		}
+	}
+
+	if f.issue == "" {
+		return true
+	}
+
+	for _, taskID := range todo.TaskIDs {
+		if f.issue == taskID || f.issue == config.DisplayTaskID(taskID) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// matchesStatus checks if any of the given todo's issues has the filter's status.
+// Closed matches all closed issues, regardless of the reason they were closed for
+func (f *listFilter) matchesStatus(todo *listedTodo) bool {
+	if f.status == "" {
+		return true
+	} else if f.status == statusMalformed {
+		return todo.IsMalformed
+	}
+
+	for _, issue := range todo.Issues {
+		if issue.Status == f.status || (f.status == "closed" && (issue.Status == "not-planned" || issue.Status == "duplicate")) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// statusName of the given status, as reported by the list command
+func statusName(status taskstatus.TaskStatus) string {
+	switch status {
+	case taskstatus.Open:
+		return "open"
+	case taskstatus.Closed:
+		return "closed"
+	case taskstatus.ClosedNotPlanned:
+		return "not-planned"
+	case taskstatus.ClosedDuplicate:
+		return "duplicate"
+	case taskstatus.Moved:
+		return "moved"
+	case taskstatus.NonExistent:
+		return "nonexistent"
+	case taskstatus.Other:
+		return "other"
+	default:
+		return "unknown"
+	}
+}
+
+// printTodoList in the given format. In the standard format, each todo is printed on a single line, followed by its issues & its comment
+func printTodoList(todos []*listedTodo, format string) error {
+	switch format {
+	case "standard":
+		for _, todo := range todos {
+			var issues []string
+			for _, issue := range todo.Issues {
+				issues = append(issues, fmt.Sprintf("%s (%s)", issue.ID, issue.Status))
+			}
+
+			if todo.IsMalformed {
+				issues = append(issues, "("+statusMalformed+")")
+			}
+
+			summary := todo.Keyword
+			if len(issues) > 0 {
+				summary += " " + strings.Join(issues, ", ")
+			}
+
+			comment := strings.Join(strings.Fields(todo.Comment), " ")
+			fmt.Printf("%s:%d: %s - %s\n", todo.Filename, todo.Line, summary, comment)
+		}
+
+		return nil
+	case "json":
+		bs, err := json.Marshal(todos)
+		if err != nil {
+			return fmt.Errorf("failed to marshal todos: %w", err)
+		}
+
+		fmt.Println(string(bs))
+		return nil
+	}
+
+	return errors.New("unrecognized output format: " + format)
+}
+
+func contains(ss []string, s string) bool {
+	for _, item := range ss {
+		if item == s {
+			return true
+		}
+	}
+
+	return false
+}
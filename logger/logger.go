@@ -6,7 +6,7 @@ import (
 )
 
 var lock sync.Mutex
-var log *Logger
+var log = &Logger{}
 
 // Logger provided simple logger.
 type Logger struct {
@@ -0,0 +1,106 @@
+package lsp
+
+import (
+	"strings"
+
+	"github.com/preslavmihaylov/todocheck/traverser/todoerrs"
+)
+
+// annotationPlaceholder is inserted after the todo keyword of a malformed todo, for the user to replace with the issue's task ID
+const annotationPlaceholder = " {task_id}:"
+
+// rangeOf the given lines, starting at the given line. It spans from the first line's indentation to the end of the last line
+func rangeOf(lines []string, linecnt int) Range {
+	if len(lines) == 0 {
+		return Range{Position{linecnt - 1, 0}, Position{linecnt - 1, 0}}
+	}
+
+	first, last := lines[0], trimLineEnding(lines[len(lines)-1])
+	indent := len(first) - len(strings.TrimLeft(first, " \t"))
+	return Range{
+		Start: Position{linecnt - 1, utf16Len(first[:indent])},
+		End:   Position{linecnt - 1 + len(lines) - 1, utf16Len(last)},
+	}
+}
+
+// removeEdit of the given todo's comment. Its lines are removed entirely, unless they contain code besides the comment
+func removeEdit(todo *todoerrs.Todo) TextEdit {
+	first := todo.Line - 1
+	wholeLines := TextEdit{Range: Range{Position{first, 0}, Position{first + len(todo.Lines), 0}}}
+
+	source := strings.Join(todo.Lines, "")
+	comment := trimLineEnding(todo.Comment)
+	start := strings.Index(source, comment)
+	if comment == "" || start < 0 {
+		return wholeLines
+	}
+
+	end := start + len(comment)
+	lineStart := strings.LastIndex(source[:start], "\n") + 1
+	lineEnd := len(source)
+	if i := strings.Index(source[end:], "\n"); i >= 0 {
+		lineEnd = end + i
+	}
+
+	before, after := source[lineStart:start], source[end:lineEnd]
+	if strings.TrimSpace(before) == "" && strings.TrimSpace(after) == "" {
+		return wholeLines
+	}
+
+	// the whitespace, separating the comment from the code before it, is removed as well
+	start -= len(before) - len(strings.TrimRight(before, " \t"))
+	return TextEdit{Range: Range{positionOf(source, start, first), positionOf(source, end, first)}}
+}
+
+// annotateEdit of the given malformed todo, which inserts a task ID placeholder after its keyword, e.g. TODO fix -> TODO {task_id}: fix.
+// It returns nil if the todo's keyword isn't found
+func annotateEdit(todo *todoerrs.Todo) *TextEdit {
+	source := strings.Join(todo.Lines, "")
+	offset := strings.Index(source, trimLineEnding(todo.Comment))
+	if todo.Comment == "" || offset < 0 {
+		offset = 0
+	}
+
+	i := strings.Index(source[offset:], todo.Keyword)
+	if todo.Keyword == "" || i < 0 {
+		return nil
+	}
+
+	// a colon, directly after the keyword, & the whitespace, separating it from the text, are replaced as well
+	start := offset + i
+	end := start + len(todo.Keyword)
+	end += len(source[end:]) - len(strings.TrimPrefix(source[end:], ":"))
+	end += len(source[end:]) - len(strings.TrimLeft(source[end:], " \t"))
+
+	newText := todo.Keyword + annotationPlaceholder
+	if end < len(source) && source[end] != '\n' && source[end] != '\r' {
+		newText += " "
+	}
+
+	first := todo.Line - 1
+	return &TextEdit{Range: Range{positionOf(source, start, first), positionOf(source, end, first)}, NewText: newText}
+}
+
+// positionOf the given byte offset in the given source, which starts at the given line
+func positionOf(source string, offset, firstLine int) Position {
+	lineStart := strings.LastIndex(source[:offset], "\n") + 1
+	return Position{firstLine + strings.Count(source[:offset], "\n"), utf16Len(source[lineStart:offset])}
+}
+
+// utf16Len of the given string, as positions count characters in UTF-16 code units
+func utf16Len(s string) int {
+	n := 0
+	for _, r := range s {
+		if r >= 0x10000 {
+			n += 2
+		} else {
+			n++
+		}
+	}
+
+	return n
+}
+
+func trimLineEnding(line string) string {
+	return strings.TrimRight(line, "\r\n")
+}
@@ -0,0 +1,61 @@
+package lsp
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// readMessage from the given reader. Messages are framed by a Content-Length header, followed by an empty line
+func readMessage(r *bufio.Reader) ([]byte, error) {
+	contentLength := -1
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+
+		line = strings.TrimRight(line, "\r\n")
+		if line == "" {
+			break
+		}
+
+		name, value, found := strings.Cut(line, ":")
+		if !found {
+			return nil, fmt.Errorf("invalid header: %q", line)
+		} else if strings.EqualFold(strings.TrimSpace(name), "Content-Length") {
+			contentLength, err = strconv.Atoi(strings.TrimSpace(value))
+			if err != nil || contentLength < 0 {
+				return nil, fmt.Errorf("invalid Content-Length header: %q", line)
+			}
+		}
+	}
+
+	if contentLength < 0 {
+		return nil, fmt.Errorf("message is missing a Content-Length header")
+	}
+
+	content := make([]byte, contentLength)
+	if _, err := io.ReadFull(r, content); err != nil {
+		return nil, fmt.Errorf("couldn't read message content: %w", err)
+	}
+
+	return content, nil
+}
+
+// writeMessage to the given writer, framed by a Content-Length header
+func writeMessage(w io.Writer, msg interface{}) error {
+	content, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("failed to marshal message: %w", err)
+	}
+
+	if _, err := fmt.Fprintf(w, "Content-Length: %d\r\n\r\n%s", len(content), content); err != nil {
+		return fmt.Errorf("couldn't write message: %w", err)
+	}
+
+	return nil
+}
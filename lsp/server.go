@@ -0,0 +1,526 @@
+// Package lsp serves the todo errors in the documents, open in an editor, via the Language Server Protocol over stdio.
+// See https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/
+package lsp
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/url"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/preslavmihaylov/todocheck/baseline"
+	todocheckerrors "github.com/preslavmihaylov/todocheck/checker/errors"
+	"github.com/preslavmihaylov/todocheck/config"
+	"github.com/preslavmihaylov/todocheck/issuetracker/taskstatus"
+	"github.com/preslavmihaylov/todocheck/logger"
+	"github.com/preslavmihaylov/todocheck/traverser/todoerrs"
+)
+
+// source of the published diagnostics, shown by editors next to their messages
+const source = "todocheck"
+
+// checkDelay after the last change of a document, before it's checked, so that it's not checked on each keystroke
+const checkDelay = 300 * time.Millisecond
+
+// errExitWithoutShutdown is returned when the client exits the server, without shutting it down first
+var errExitWithoutShutdown = errors.New("received exit notification before a shutdown request")
+
+// Server of the todo errors in the documents, open in the client
+type Server struct {
+	in        io.Reader
+	out       io.Writer
+	basepath  string
+	version   string
+	baseline  *baseline.Baseline
+	traverser *todoerrs.Traverser
+
+	// documents open in the client, keyed by their URI
+	documents map[string]*document
+
+	// todoErrs of the document, being checked
+	todoErrs []*todocheckerrors.TODO
+
+	// checkDue fires once the changed documents are due to be checked. It's nil if none of them changed
+	checkDue <-chan time.Time
+
+	isShutdown bool
+}
+
+// document, open in the client
+type document struct {
+	uri     string
+	version int
+	text    string
+
+	// filename the document is checked as. It's empty for documents, which are not files
+	filename string
+
+	// isChanged is true if the document changed since it was last checked
+	isChanged bool
+
+	todoErrs []*todocheckerrors.TODO
+	todos    []*todoerrs.Todo
+}
+
+// NewServer, which reads the client's messages from in & writes its own to out.
+// Todo errors in the given baseline are not published. The baseline can be nil
+func NewServer(in io.Reader, out io.Writer, basepath, version string, b *baseline.Baseline) *Server {
+	return &Server{
+		in:        in,
+		out:       out,
+		basepath:  basepath,
+		version:   version,
+		baseline:  b,
+		documents: map[string]*document{},
+	}
+}
+
+// Collect the given todo error of the document, being checked. It's the callback of the server's traverser
+func (s *Server) Collect(todoErr *todocheckerrors.TODO) error {
+	s.todoErrs = append(s.todoErrs, todoErr)
+	return nil
+}
+
+// Serve the client's requests, checking its documents with the given traverser, until it exits or the context is done.
+// The issue statuses are fetched again & the documents are checked again every refreshInterval, unless it's zero
+func (s *Server) Serve(ctx context.Context, traverser *todoerrs.Traverser, refreshInterval time.Duration) error {
+	s.traverser = traverser
+
+	var refresh <-chan time.Time
+	if refreshInterval > 0 {
+		refreshTicker := time.NewTicker(refreshInterval)
+		defer refreshTicker.Stop()
+		refresh = refreshTicker.C
+	}
+
+	// messages are read in the background, so that a done context stops the server while it's waiting for the client
+	messages := make(chan []byte)
+	readErr := make(chan error, 1)
+	go func() {
+		r := bufio.NewReader(s.in)
+		for {
+			content, err := readMessage(r)
+			if err != nil {
+				readErr <- err
+				return
+			}
+
+			select {
+			case messages <- content:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case err := <-readErr:
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+
+			return fmt.Errorf("couldn't read message: %w", err)
+		case content := <-messages:
+			exit, err := s.handle(ctx, content)
+			if err != nil || exit {
+				return err
+			}
+		case <-s.checkDue:
+			if err := s.checkChanged(ctx); err != nil {
+				return err
+			}
+		case <-refresh:
+			logger.Info("Refreshing issue statuses")
+			s.traverser.RefreshStatuses()
+			if err := s.checkAll(ctx); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// handle the given message & return true if the client exited the server
+func (s *Server) handle(ctx context.Context, content []byte) (exit bool, err error) {
+	var msg message
+	if err := json.Unmarshal(content, &msg); err != nil {
+		return false, s.respond(nil, nil, &responseError{codeParseError, fmt.Sprintf("invalid message: %s", err)})
+	} else if msg.ID == nil {
+		return s.handleNotification(ctx, &msg)
+	}
+
+	// requests are answered based on the current text of the documents, so the changed ones are checked first
+	if err := s.checkChanged(ctx); err != nil {
+		return false, err
+	}
+
+	result, respErr := s.handleRequest(ctx, &msg)
+	return false, s.respond(msg.ID, result, respErr)
+}
+
+func (s *Server) handleRequest(ctx context.Context, msg *message) (interface{}, *responseError) {
+	if s.isShutdown {
+		return nil, &responseError{codeInvalidRequest, "server is shut down"}
+	}
+
+	switch msg.Method {
+	case "initialize":
+		return &InitializeResult{
+			Capabilities: ServerCapabilities{
+				TextDocumentSync:   TextDocumentSyncOptions{OpenClose: true, Change: textDocumentSyncFull, Save: true},
+				HoverProvider:      true,
+				CodeActionProvider: true,
+			},
+			ServerInfo: ServerInfo{Name: source, Version: s.version},
+		}, nil
+	case "shutdown":
+		s.isShutdown = true
+		return nil, nil
+	case "textDocument/hover":
+		var params TextDocumentPositionParams
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			return nil, invalidParams(msg, err)
+		}
+
+		return s.hover(ctx, &params), nil
+	case "textDocument/codeAction":
+		var params CodeActionParams
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			return nil, invalidParams(msg, err)
+		}
+
+		return s.codeActions(&params), nil
+	default:
+		return nil, &responseError{codeMethodNotFound, "unsupported method: " + msg.Method}
+	}
+}
+
+func (s *Server) handleNotification(ctx context.Context, msg *message) (exit bool, err error) {
+	switch msg.Method {
+	case "exit":
+		if !s.isShutdown {
+			return true, errExitWithoutShutdown
+		}
+
+		return true, nil
+	case "textDocument/didOpen":
+		var params DidOpenTextDocumentParams
+		if err := unmarshalNotificationParams(msg, &params); err != nil {
+			return false, nil
+		}
+
+		doc := &document{
+			uri:      params.TextDocument.URI,
+			version:  params.TextDocument.Version,
+			text:     params.TextDocument.Text,
+			filename: s.filenameOf(params.TextDocument.URI),
+		}
+
+		s.documents[doc.uri] = doc
+		return false, s.check(ctx, doc)
+	case "textDocument/didChange":
+		var params DidChangeTextDocumentParams
+		if err := unmarshalNotificationParams(msg, &params); err != nil {
+			return false, nil
+		}
+
+		doc := s.documents[params.TextDocument.URI]
+		if doc == nil || len(params.ContentChanges) == 0 {
+			return false, nil
+		}
+
+		doc.version = params.TextDocument.Version
+		doc.text = params.ContentChanges[len(params.ContentChanges)-1].Text
+		doc.isChanged = true
+		s.checkDue = time.After(checkDelay)
+		return false, nil
+	case "textDocument/didClose":
+		var params DidCloseTextDocumentParams
+		if err := unmarshalNotificationParams(msg, &params); err != nil {
+			return false, nil
+		}
+
+		delete(s.documents, params.TextDocument.URI)
+		return false, s.notify("textDocument/publishDiagnostics", &PublishDiagnosticsParams{URI: params.TextDocument.URI, Diagnostics: []Diagnostic{}})
+	case "textDocument/didSave":
+		// saved documents are checked right away, rather than after the check delay
+		return false, s.checkChanged(ctx)
+	}
+
+	return false, nil
+}
+
+// checkAll open documents, in the order of their URIs
+func (s *Server) checkAll(ctx context.Context) error {
+	for _, uri := range s.sortedURIs() {
+		if err := s.check(ctx, s.documents[uri]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// checkChanged documents, which changed since they were last checked, in the order of their URIs
+func (s *Server) checkChanged(ctx context.Context) error {
+	s.checkDue = nil
+	for _, uri := range s.sortedURIs() {
+		if doc := s.documents[uri]; doc.isChanged {
+			if err := s.check(ctx, doc); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// sortedURIs of the open documents
+func (s *Server) sortedURIs() []string {
+	uris := make([]string, 0, len(s.documents))
+	for uri := range s.documents {
+		uris = append(uris, uri)
+	}
+
+	sort.Strings(uris)
+	return uris
+}
+
+// check the given document for todo errors & publish them as diagnostics.
+// A failed check is logged & published as no diagnostics, so that it doesn't stop the server
+func (s *Server) check(ctx context.Context, doc *document) error {
+	s.todoErrs = nil
+	doc.todoErrs, doc.todos, doc.isChanged = nil, nil, false
+	if doc.filename != "" {
+		if err := s.traverser.TraverseContent(ctx, doc.filename, []byte(doc.text)); err != nil {
+			log.Printf("couldn't check %s: %s\n", doc.uri, err)
+			s.todoErrs = nil
+		} else {
+			doc.todos = s.traverser.Todos()
+		}
+	}
+
+	if s.baseline != nil {
+		s.baseline.ResetMatches()
+	}
+
+	diagnostics := []Diagnostic{}
+	for _, todoErr := range s.todoErrs {
+		if s.baseline != nil && !todoErr.IsWarning() && s.baseline.Match(todoErr) {
+			continue
+		}
+
+		doc.todoErrs = append(doc.todoErrs, todoErr)
+		diagnostics = append(diagnostics, diagnosticOf(todoErr))
+	}
+
+	version := doc.version
+	return s.notify("textDocument/publishDiagnostics", &PublishDiagnosticsParams{URI: doc.uri, Version: &version, Diagnostics: diagnostics})
+}
+
+// hover over the todo at the given position, showing the status, title & URL of the issues it references.
+// It returns nil if there's no todo, referencing issues, at the position
+func (s *Server) hover(ctx context.Context, params *TextDocumentPositionParams) *Hover {
+	doc := s.documents[params.TextDocument.URI]
+	if doc == nil {
+		return nil
+	}
+
+	for _, todo := range doc.todos {
+		r := rangeOf(todo.Lines, todo.Line)
+		if params.Position.Line < r.Start.Line || params.Position.Line > r.End.Line || len(todo.TaskIDs) == 0 {
+			continue
+		}
+
+		var issues []string
+		for _, taskID := range todo.TaskIDs {
+			issues = append(issues, s.describeIssue(ctx, taskID))
+		}
+
+		return &Hover{Contents: MarkupContent{Kind: "markdown", Value: strings.Join(issues, "\n\n---\n\n")}, Range: &r}
+	}
+
+	return nil
+}
+
+// describeIssue with the given task ID in markdown
+func (s *Server) describeIssue(ctx context.Context, taskID string) string {
+	description := fmt.Sprintf("**%s**", config.DisplayTaskID(taskID))
+	if res, err := s.traverser.IssueStatus(ctx, taskID); err != nil {
+		description += " - status unknown: " + err.Error()
+	} else {
+		description += " - " + describeStatus(res)
+		if res.Title != "" {
+			description += "\n\n" + res.Title
+		}
+	}
+
+	if webURL := s.traverser.WebURLFor(taskID); webURL != "" {
+		description += "\n\n" + webURL
+	}
+
+	return description
+}
+
+// codeActions for the todo errors in the given range, which remove the erroneous todos or annotate the malformed ones
+func (s *Server) codeActions(params *CodeActionParams) []CodeAction {
+	doc := s.documents[params.TextDocument.URI]
+	if doc == nil {
+		return nil
+	}
+
+	var actions []CodeAction
+	for _, todo := range doc.todos {
+		r := rangeOf(todo.Lines, todo.Line)
+		if r.End.Line < params.Range.Start.Line || r.Start.Line > params.Range.End.Line {
+			continue
+		}
+
+		var diagnostics []Diagnostic
+		isMalformed := false
+		for _, todoErr := range doc.todoErrs {
+			if todoErr.Line() == todo.Line && todoErr.Type() != todocheckerrors.TODOErrTypeUnusedSuppression {
+				diagnostics = append(diagnostics, diagnosticOf(todoErr))
+				isMalformed = isMalformed || todoErr.Type() == todocheckerrors.TODOErrTypeMalformed
+			}
+		}
+
+		if len(diagnostics) == 0 {
+			continue
+		}
+
+		if isMalformed {
+			if edit := annotateEdit(todo); edit != nil {
+				actions = append(actions, s.codeAction(doc, "Convert to annotated TODO", diagnostics, *edit))
+			}
+		}
+
+		actions = append(actions, s.codeAction(doc, "Remove TODO", diagnostics, removeEdit(todo)))
+	}
+
+	return actions
+}
+
+func (s *Server) codeAction(doc *document, title string, diagnostics []Diagnostic, edit TextEdit) CodeAction {
+	return CodeAction{
+		Title:       title,
+		Kind:        codeActionKindQuickFix,
+		Diagnostics: diagnostics,
+		Edit:        &WorkspaceEdit{Changes: map[string][]TextEdit{doc.uri: {edit}}},
+	}
+}
+
+// filenameOf the document with the given URI. Files in the basepath are relative to it, the way they're named when traversing it,
+// so that configured ignored paths, overrides & the baseline apply to them. It's empty for documents, which are not files
+func (s *Server) filenameOf(uri string) string {
+	u, err := url.Parse(uri)
+	if err != nil || u.Scheme != "file" {
+		return ""
+	}
+
+	path := u.Path
+	if len(path) >= 3 && path[0] == '/' && path[2] == ':' {
+		// windows paths start with a drive letter, e.g. file:///C:/project/main.go
+		path = path[1:]
+	}
+
+	path = filepath.FromSlash(path)
+	if base, err := filepath.Abs(s.basepath); err == nil {
+		rel, err := filepath.Rel(base, path)
+		if err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return filepath.Join(s.basepath, rel)
+		}
+	}
+
+	return path
+}
+
+func (s *Server) respond(id *json.RawMessage, result interface{}, respErr *responseError) error {
+	if respErr != nil {
+		return writeMessage(s.out, &errorResponse{JSONRPC: "2.0", ID: id, Error: respErr})
+	}
+
+	return writeMessage(s.out, &resultResponse{JSONRPC: "2.0", ID: id, Result: result})
+}
+
+func (s *Server) notify(method string, params interface{}) error {
+	return writeMessage(s.out, &notification{JSONRPC: "2.0", Method: method, Params: params})
+}
+
+// unmarshalNotificationParams & log invalid ones, as notifications can't be responded to
+func unmarshalNotificationParams(msg *message, params interface{}) error {
+	err := json.Unmarshal(msg.Params, params)
+	if err != nil {
+		log.Printf("invalid %s params: %s\n", msg.Method, err)
+	}
+
+	return err
+}
+
+func invalidParams(msg *message, err error) *responseError {
+	return &responseError{codeInvalidParams, fmt.Sprintf("invalid %s params: %s", msg.Method, err)}
+}
+
+// diagnosticOf the given todo error, spanning the todo's lines
+func diagnosticOf(todoErr *todocheckerrors.TODO) Diagnostic {
+	severity := SeverityError
+	if todoErr.IsWarning() {
+		severity = SeverityWarning
+	}
+
+	message := string(todoErr.Type())
+	if issueID := todoErr.IssueID(); issueID != "" {
+		message += " (" + issueID + ")"
+	}
+
+	if details := todoErr.Message(); details != "" {
+		message += ": " + details
+	}
+
+	return Diagnostic{
+		Range:    rangeOf(todoErr.Lines(), todoErr.Line()),
+		Severity: severity,
+		Source:   source,
+		Message:  message,
+	}
+}
+
+// describeStatus of the given issue
+func describeStatus(res taskstatus.Result) string {
+	switch res.Status {
+	case taskstatus.Open:
+		return "open"
+	case taskstatus.Closed:
+		return "closed"
+	case taskstatus.ClosedNotPlanned:
+		return "closed as not planned"
+	case taskstatus.ClosedDuplicate:
+		if res.LinkedTaskID != "" {
+			return "closed as duplicate of " + res.LinkedTaskID
+		}
+
+		return "closed as duplicate"
+	case taskstatus.Moved:
+		if res.LinkedTaskID != "" {
+			return "moved to " + res.LinkedTaskID
+		}
+
+		return "moved"
+	case taskstatus.NonExistent:
+		return "doesn't exist"
+	case taskstatus.Other:
+		return "neither open nor closed"
+	default:
+		return "unknown"
+	}
+}
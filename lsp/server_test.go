@@ -0,0 +1,209 @@
+package lsp
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/preslavmihaylov/todocheck/config"
+	"github.com/preslavmihaylov/todocheck/fetcher"
+	"github.com/preslavmihaylov/todocheck/issuetracker/taskstatus"
+	"github.com/preslavmihaylov/todocheck/traverser/todoerrs"
+)
+
+const testDocument = `package main
+
+// TODO fix this
+func main() {} // TODO #1: closed issue
+
+// TODO #2: open issue
+`
+
+type mockFetcher struct{}
+
+func (f mockFetcher) Fetch(ctx context.Context, taskID string) (taskstatus.Result, error) {
+	if taskID == "#1" {
+		return taskstatus.Result{Status: taskstatus.Closed}, nil
+	}
+
+	return taskstatus.Result{Status: taskstatus.Open, Title: "Support editors"}, nil
+}
+
+func TestServe(t *testing.T) {
+	dir := t.TempDir()
+	uri := "file://" + filepath.ToSlash(filepath.Join(dir, "main.go"))
+	docID := TextDocumentIdentifier{URI: uri}
+	responses := serve(t, dir, nil,
+		request(1, "initialize", struct{}{}),
+		notify("initialized", struct{}{}),
+		notify("textDocument/didOpen", DidOpenTextDocumentParams{TextDocumentItem{URI: uri, LanguageID: "go", Version: 1, Text: testDocument}}),
+		request(2, "textDocument/hover", TextDocumentPositionParams{docID, Position{5, 4}}),
+		request(3, "textDocument/codeAction", CodeActionParams{docID, Range{Position{2, 0}, Position{3, 0}}}),
+		notify("textDocument/didClose", DidCloseTextDocumentParams{docID}),
+		request(4, "shutdown", nil),
+		notify("exit", nil),
+	)
+
+	if len(responses) != 6 {
+		t.Fatalf("Got %d messages from the server, expected 6", len(responses))
+	}
+
+	var diagnostics PublishDiagnosticsParams
+	unmarshal(t, responses[1]["params"], &diagnostics)
+	wantDiagnostics := []Diagnostic{
+		{Range{Position{2, 0}, Position{2, 16}}, SeverityError, source, "Malformed todo: TODO should match pattern - TODO {task_id}:"},
+		{Range{Position{3, 0}, Position{3, 39}}, SeverityError, source, "Issue is closed (#1)"},
+	}
+	if !reflect.DeepEqual(diagnostics.Diagnostics, wantDiagnostics) {
+		t.Errorf("Got diagnostics %+v, expected %+v", diagnostics.Diagnostics, wantDiagnostics)
+	}
+
+	var hover Hover
+	unmarshal(t, responses[2]["result"], &hover)
+	wantHover := "**#2** - open\n\nSupport editors\n\nhttps://github.com/user/repo/issues/2"
+	if hover.Contents.Value != wantHover {
+		t.Errorf("Got hover %q, expected %q", hover.Contents.Value, wantHover)
+	}
+
+	var actions []CodeAction
+	unmarshal(t, responses[3]["result"], &actions)
+	wantEdits := map[string]TextEdit{
+		"Convert to annotated TODO": {Range{Position{2, 3}, Position{2, 8}}, "TODO {task_id}: "},
+		"Remove TODO":               {Range{Position{2, 0}, Position{3, 0}}, ""},
+	}
+	if len(actions) != 3 {
+		t.Fatalf("Got %d code actions, expected 3", len(actions))
+	}
+	for i, action := range actions[:2] {
+		if got := action.Edit.Changes[uri]; len(got) != 1 || got[0] != wantEdits[action.Title] {
+			t.Errorf("Got edits %+v for code action %d %q, expected %+v", got, i, action.Title, wantEdits[action.Title])
+		}
+	}
+	if got, want := actions[2].Edit.Changes[uri], (TextEdit{Range{Position{3, 14}, Position{3, 39}}, ""}); len(got) != 1 || got[0] != want {
+		t.Errorf("Got edits %+v for removing the todo after code, expected %+v", got, want)
+	}
+
+	unmarshal(t, responses[4]["params"], &diagnostics)
+	if diagnostics.URI != uri || len(diagnostics.Diagnostics) != 0 {
+		t.Errorf("Expected the diagnostics of the closed document to be cleared, got %+v", diagnostics)
+	}
+}
+
+func TestServeChecksChangedDocumentsBeforeRequests(t *testing.T) {
+	dir := t.TempDir()
+	uri := "file://" + filepath.ToSlash(filepath.Join(dir, "main.go"))
+	docID := VersionedTextDocumentIdentifier{URI: uri, Version: 2}
+	responses := serve(t, dir, nil,
+		notify("textDocument/didOpen", DidOpenTextDocumentParams{TextDocumentItem{URI: uri, LanguageID: "go", Version: 1, Text: "package main\n"}}),
+		notify("textDocument/didChange", DidChangeTextDocumentParams{docID, []TextDocumentContentChangeEvent{{Text: "// TODO\n"}}}),
+		notify("textDocument/didChange", DidChangeTextDocumentParams{docID, []TextDocumentContentChangeEvent{{Text: testDocument}}}),
+		request(1, "textDocument/hover", TextDocumentPositionParams{TextDocumentIdentifier{uri}, Position{5, 4}}),
+	)
+
+	if len(responses) != 3 {
+		t.Fatalf("Got %d messages from the server, expected 3", len(responses))
+	}
+
+	var diagnostics PublishDiagnosticsParams
+	unmarshal(t, responses[1]["params"], &diagnostics)
+	if diagnostics.Version == nil || *diagnostics.Version != 2 || len(diagnostics.Diagnostics) != 2 {
+		t.Errorf("Got diagnostics %+v, expected the 2 errors of the changed document", diagnostics)
+	}
+
+	var hover Hover
+	unmarshal(t, responses[2]["result"], &hover)
+	if !strings.HasPrefix(hover.Contents.Value, "**#2**") {
+		t.Errorf("Got hover %q, expected the changed document's todo", hover.Contents.Value)
+	}
+}
+
+func TestServeExitWithoutShutdown(t *testing.T) {
+	if responses := serve(t, t.TempDir(), errExitWithoutShutdown, notify("exit", nil)); len(responses) != 0 {
+		t.Errorf("Got %d messages from the server, expected none", len(responses))
+	}
+}
+
+func TestFilenameOf(t *testing.T) {
+	dir := t.TempDir()
+	s := NewServer(nil, nil, dir, "", nil)
+	tests := []struct {
+		uri, want string
+	}{
+		{"file://" + filepath.ToSlash(filepath.Join(dir, "pkg", "main.go")), filepath.Join(dir, "pkg", "main.go")},
+		{"file:///other/main.go", filepath.FromSlash("/other/main.go")},
+		{"untitled:Untitled-1", ""},
+	}
+	for _, tt := range tests {
+		if got := s.filenameOf(tt.uri); got != tt.want {
+			t.Errorf("Got filename %q for %s, expected %q", got, tt.uri, tt.want)
+		}
+	}
+}
+
+// serve the given messages & return the messages, the server wrote
+func serve(t *testing.T, dir string, wantErr error, messages ...interface{}) []map[string]json.RawMessage {
+	s, traverser := newTestServer(t, dir, messages...)
+	if err := s.Serve(context.Background(), traverser, 0); !errors.Is(err, wantErr) {
+		t.Fatalf("Got error %v while serving, expected %v", err, wantErr)
+	}
+
+	var written []map[string]json.RawMessage
+	r := bufio.NewReader(s.out.(*bytes.Buffer))
+	for {
+		content, err := readMessage(r)
+		if err != nil {
+			break
+		}
+
+		var msg map[string]json.RawMessage
+		unmarshal(t, content, &msg)
+		written = append(written, msg)
+	}
+
+	return written
+}
+
+// newTestServer, which reads the given messages, & its traverser for a github project in the given dir
+func newTestServer(t *testing.T, dir string, messages ...interface{}) (*Server, *todoerrs.Traverser) {
+	cfgPath := filepath.Join(dir, ".todocheck.yaml")
+	if err := os.WriteFile(cfgPath, []byte("origin: github.com/user/repo\nissue_tracker: GITHUB\n"), 0644); err != nil {
+		t.Fatalf("Couldn't write configuration: %v", err)
+	}
+
+	cfg, err := config.NewOfflineLocal(cfgPath, dir)
+	if err != nil {
+		t.Fatalf("Couldn't read configuration: %v", err)
+	}
+
+	var in bytes.Buffer
+	for _, msg := range messages {
+		if err := writeMessage(&in, msg); err != nil {
+			t.Fatalf("Couldn't write message: %v", err)
+		}
+	}
+
+	s := NewServer(&in, &bytes.Buffer{}, dir, "", nil)
+	return s, todoerrs.NewTraverser(fetcher.NewPool(mockFetcher{}, 1), cfg, time.Now(), s.Collect)
+}
+
+func request(id int, method string, params interface{}) interface{} {
+	return map[string]interface{}{"jsonrpc": "2.0", "id": id, "method": method, "params": params}
+}
+
+func notify(method string, params interface{}) interface{} {
+	return map[string]interface{}{"jsonrpc": "2.0", "method": method, "params": params}
+}
+
+func unmarshal(t *testing.T, content []byte, v interface{}) {
+	if err := json.Unmarshal(content, v); err != nil {
+		t.Fatalf("Couldn't unmarshal %s: %v", strings.TrimSpace(string(content)), err)
+	}
+}
@@ -1,21 +1,35 @@
 package main
 
 import (
+	"bufio"
+	"context"
 	"errors"
 	"flag"
 	"fmt"
+	"io"
 	"log"
 	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/fatih/color"
 	"github.com/preslavmihaylov/todocheck/authmanager"
+	"github.com/preslavmihaylov/todocheck/baseline"
 	todocheckerrors "github.com/preslavmihaylov/todocheck/checker/errors"
 	"github.com/preslavmihaylov/todocheck/config"
+	"github.com/preslavmihaylov/todocheck/diff"
 	"github.com/preslavmihaylov/todocheck/fetcher"
+	"github.com/preslavmihaylov/todocheck/fetcher/cache"
+	"github.com/preslavmihaylov/todocheck/githook"
+	"github.com/preslavmihaylov/todocheck/issuetracker"
 	"github.com/preslavmihaylov/todocheck/issuetracker/factory"
 	"github.com/preslavmihaylov/todocheck/logger"
+	"github.com/preslavmihaylov/todocheck/lsp"
 	"github.com/preslavmihaylov/todocheck/traverser/todoerrs"
 	"github.com/preslavmihaylov/todocheck/validation"
+	"github.com/preslavmihaylov/todocheck/watcher"
 )
 
 // set dynamically on build time. See Makefile for more info
@@ -23,74 +37,570 @@ var version string
 
 // TODO:
 // * Add a --closes option which indicates that an issue is to be closed as a result of a PR
-// * Add caching for task statuses
 func main() {
+	args := os.Args[1:]
+	if len(args) > 0 && args[0] == "install-hook" {
+		installHook(args[1:])
+		return
+	}
+
+	// the baseline command records the current todo errors in a baseline instead of reporting them,
+	// the watch command keeps checking the changed files until it's interrupted,
+	// the lsp command serves the todo errors in the documents, open in an editor
+	// & the list command reports all todos instead of their errors
+	isBaselineCommand := len(args) > 0 && args[0] == "baseline"
+	isWatchCommand := len(args) > 0 && args[0] == "watch"
+	isLSPCommand := len(args) > 0 && args[0] == "lsp"
+	isListCommand := len(args) > 0 && args[0] == "list"
+	if isBaselineCommand || isWatchCommand || isLSPCommand || isListCommand {
+		args = args[1:]
+	}
+
+	// the lsp command talks to the editor over stdin & stdout, so everything else is printed to stderr instead
+	// & tokens, which are not configured, can't be prompted for
+	lspOut := os.Stdout
+	if isLSPCommand {
+		os.Stdout = os.Stderr
+		authmanager.PromptForTokens = false
+	}
+
 	fs := flag.NewFlagSet("", flag.ExitOnError)
 	var basepath = fs.String("basepath", ".", "The path for the project to todocheck. Defaults to current directory")
 	var cfgPath = fs.String("config", "", "The project configuration file to use. Will use the one from the basepath if not specified")
 	var format = fs.String("format", "standard", "The output format to use. Available formats - standard, json")
+	var noCache = fs.Bool("no-cache", false, "Don't read or write the task status cache")
+	var refreshCache = fs.Bool("refresh-cache", false, "Ignore cached task statuses & fetch them again. Fetched statuses are still cached")
+	var offline = fs.Bool("offline", false, "Don't contact the issue tracker. Only malformed & expired todos and invalid issue references are reported")
+	var timeout = fs.Duration("timeout", 0, "Stop checking todos after the given duration (e.g. 5m) & report the errors found so far. Disabled by default")
+	var diffBase = fs.String("diff-base", "", "Only report errors of todos on the lines changed relative to the given git ref, e.g. origin/main")
+	var diffFile = fs.String("diff-file", "", "Only report errors of todos on the lines changed in the given unified diff, whose paths are relative to the basepath. Use - to read it from stdin")
+	var staged = fs.Bool("staged", false, "Only check the files, staged for the next commit, using their staged content. Useful in a git pre-commit hook")
+	var filesFrom = fs.String("files-from", "", "Only check the files & directories listed in the given file, one per line. Use - to read the list from stdin")
+	var stdin = fs.Bool("stdin", false, "Check the content read from stdin, e.g. an unsaved editor buffer. Requires --stdin-filename")
+	var stdinFilename = fs.String("stdin-filename", "", "The name of the file, whose content is read from stdin. It determines the language the content is checked as")
+	var pruneBaseline = fs.Bool("prune-baseline", false, "Remove the errors, which are no longer present, from the configured baseline")
+	var reportUnusedSuppressions = fs.Bool("report-unused-suppressions", false, "Report todocheck:ignore & todocheck:disable directives, which don't suppress any todo errors")
+	var nowFlag = fs.String("now", "", "The date (e.g. 2026-12-31) or time (RFC3339) todo expiry dates are checked against. Defaults to the current time")
 	var verboseRequested = fs.Bool("verbose", false, "Make todocheck more talkative")
 	var versionRequested = fs.Bool("version", false, "Show the current version of todocheck")
 	fs.BoolVar(versionRequested, "v", *versionRequested, "Show the current version of todocheck (shorthand)")
 
-	if err := fs.Parse(os.Args[1:]); err != nil {
-		log.Fatal(err)
+	var baselineFile *string
+	if isBaselineCommand {
+		baselineFile = fs.String("write", "", "The file to write the baseline to. Defaults to the configured baseline or "+baseline.DefaultFile+" in the basepath")
 	}
 
-	if args := fs.Args(); len(args) > 0 {
-		log.Fatalf("Unexpected arguments: %s\n", args)
+	var pollInterval, refreshInterval *time.Duration
+	if isWatchCommand {
+		pollInterval = fs.Duration("poll-interval", time.Second, "How often to check the watched files for changes")
+	}
+
+	if isWatchCommand || isLSPCommand {
+		refreshInterval = fs.Duration("refresh-interval", 5*time.Minute, "How often to fetch the issue statuses again & check all files. 0 disables refreshing")
+	}
+
+	filter := &listFilter{}
+	if isListCommand {
+		fs.StringVar(&filter.issue, "issue", "", "Only list the todos, which reference the given issue, e.g. ABC-123")
+		fs.StringVar(&filter.status, "status", "", "Only list the todos, which reference an issue with the given status, or malformed todos. "+
+			"Available statuses - "+strings.Join(listStatuses, ", "))
+		fs.StringVar(&filter.keyword, "keyword", "", "Only list the todos with the given keyword from custom_todos, e.g. FIXME")
+		fs.StringVar(&filter.path, "path", "", "Only list the todos in the given file or directory or in the files matching the given glob, e.g. **/*.py")
+	}
+
+	if err := fs.Parse(args); err != nil {
+		log.Fatal(err)
 	}
 
 	logger.Setup(*verboseRequested)
 
+	// the first interrupt stops the run gracefully, including its setup, e.g. acquiring tokens & loading changes,
+	// while a second one terminates todocheck immediately
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		stop()
+	}()
+
 	if *versionRequested {
 		fmt.Println(version)
 		os.Exit(0)
 	}
 
-	localCfg, err := config.NewLocal(*cfgPath, *basepath)
+	now, err := parseNow(*nowFlag)
 	if err != nil {
-		log.Fatalf("couldn't open configuration file: %s\n", err)
+		log.Fatalf("invalid --now value: %s\n", err)
 	}
 
-	tracker, err := factory.NewIssueTrackerFrom(localCfg.IssueTracker, localCfg.Auth, localCfg.Origin)
-	if err != nil {
-		log.Fatalf("couldn't create new issue tracker: %s\n", err)
+	loadCfg := config.NewLocal
+	if *offline {
+		loadCfg = config.NewOfflineLocal
 	}
 
-	err = authmanager.AcquireToken(localCfg, tracker)
+	localCfg, err := loadCfg(*cfgPath, *basepath)
 	if err != nil {
-		log.Fatalf("couldn't acquire token from config: %s\n", err)
+		log.Fatalf("couldn't open configuration file: %s\n", err)
+	}
+
+	if *reportUnusedSuppressions {
+		localCfg.ReportUnusedSuppressions = true
 	}
 
-	if errors := validation.Validate(localCfg, tracker); len(errors) > 0 {
-		for _, err := range errors {
-			log.Println(err)
+	isDiff := *diffBase != "" || *diffFile != ""
+	if *diffBase != "" && *diffFile != "" {
+		log.Fatalf("--diff-base & --diff-file can't be used together\n")
+	} else if isDiff && isBaselineCommand {
+		log.Fatalf("the baseline command records all todo errors & can't be used with --diff-base or --diff-file\n")
+	} else if *staged && (isDiff || isBaselineCommand) {
+		log.Fatalf("--staged can't be used with --diff-base, --diff-file or the baseline command\n")
+	}
+
+	// file arguments & --files-from limit the traversal to the given files & directories instead of the basepath
+	paths := fs.Args()
+	if *filesFrom != "" {
+		if *filesFrom == "-" && *diffFile == "-" {
+			log.Fatalf("--files-from & --diff-file can't both be read from stdin\n")
 		}
 
-		os.Exit(1)
+		listed, err := readFilesFrom(*filesFrom)
+		if err != nil {
+			log.Fatalf("couldn't read files list: %s\n", err)
+		}
+
+		paths = append(paths, listed...)
+	}
+
+	for i := range paths {
+		paths[i] = filepath.Clean(paths[i])
+	}
+
+	if *stdin != (*stdinFilename != "") {
+		log.Fatalf("--stdin & --stdin-filename must be used together\n")
+	} else if *stdin && (len(paths) > 0 || *filesFrom != "" || isDiff || *staged) {
+		log.Fatalf("--stdin can't be used with file arguments, --files-from, --diff-base, --diff-file or --staged\n")
+	} else if (len(paths) > 0 || *filesFrom != "" || *stdin) && (isBaselineCommand || *pruneBaseline) {
+		log.Fatalf("file arguments, --files-from & --stdin only check some files & can't be used with --prune-baseline or the baseline command\n")
+	} else if isWatchCommand && (isDiff || *staged || *stdin || *pruneBaseline || *timeout > 0) {
+		log.Fatalf("the watch command can't be used with --diff-base, --diff-file, --staged, --stdin, --prune-baseline or --timeout\n")
+	} else if isWatchCommand && *pollInterval <= 0 {
+		log.Fatalf("--poll-interval must be positive\n")
+	} else if isListCommand && *pruneBaseline {
+		log.Fatalf("the list command reports all todos & can't be used with --prune-baseline\n")
+	} else if err := filter.validate(); err != nil {
+		log.Fatalf("invalid list filter: %s\n", err)
+	} else if isLSPCommand && (len(paths) > 0 || *filesFrom != "" || isDiff || *staged || *stdin || *pruneBaseline || *timeout > 0) {
+		log.Fatalf("the lsp command checks the documents, open in the editor, & can't be used with file arguments, --files-from, " +
+			"--diff-base, --diff-file, --staged, --stdin, --prune-baseline or --timeout\n")
+	}
+
+	var stdinContent []byte
+	if *stdin {
+		stdinContent, err = io.ReadAll(os.Stdin)
+		if err != nil {
+			log.Fatalf("couldn't read stdin: %s\n", err)
+		}
+	}
+
+	if len(paths) == 0 && *filesFrom == "" {
+		paths = []string{*basepath}
 	}
 
-	f := fetcher.NewFetcher(tracker)
+	var todoBaseline *baseline.Baseline
+	if *pruneBaseline && (isBaselineCommand || *offline || isDiff || *staged || localCfg.Baseline == "") {
+		log.Fatalf("--prune-baseline requires a configured baseline & can't be used with --offline, --diff-base, --diff-file, --staged or the baseline command\n")
+	} else if !isBaselineCommand && localCfg.Baseline != "" {
+		todoBaseline, err = baseline.Load(localCfg.Baseline, *basepath)
+		if err != nil {
+			log.Fatalf("couldn't load baseline: %s\n", err)
+		}
+	}
+
+	if *timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, *timeout)
+		defer cancel()
+	}
 
 	todoErrs := []*todocheckerrors.TODO{}
-	traverser := todoerrs.NewTraverser(f, localCfg.IgnoredPaths, localCfg.CustomTodos, localCfg.MatchCaseInsensitive, func(todoErr *todocheckerrors.TODO) error {
+	baselined := 0
+	callback := func(todoErr *todocheckerrors.TODO) error {
+		if todoBaseline != nil && !todoErr.IsWarning() && todoBaseline.Match(todoErr) {
+			baselined++
+			return nil
+		}
+
 		todoErrs = append(todoErrs, todoErr)
 		return nil
-	})
+	}
 
-	err = traverser.TraversePath(*basepath)
-	if err != nil {
+	// the watch session matches the baseline against all errors on each iteration instead
+	var session *watchSession
+	if isWatchCommand {
+		session = newWatchSession(todoBaseline, *format)
+		callback = session.collect
+	}
+
+	// the lsp server collects the errors of each document it checks & matches them against the baseline itself
+	var lspServer *lsp.Server
+	if isLSPCommand {
+		lspServer = lsp.NewServer(os.Stdin, lspOut, *basepath, version, todoBaseline)
+		callback = lspServer.Collect
+	}
+
+	// the list command reports the todos found by the traversal, rather than their errors
+	if isListCommand {
+		callback = func(*todocheckerrors.TODO) error { return nil }
+	}
+
+	// issue statuses are kept in memory in watch & lsp mode, so cached ones are only written, in order to notice closed issues on refresh
+	refreshStatusCache := *refreshCache || isWatchCommand || isLSPCommand
+
+	var traverser *todoerrs.Traverser
+	var statusCaches []*cache.Cache
+	if *offline {
+		exitOnValidationErrors(validation.ValidateOffline(localCfg))
+		traverser = todoerrs.NewOfflineTraverser(localCfg, now, callback)
+	} else {
+		tracker, err := factory.NewIssueTrackerFrom(ctx, localCfg.IssueTracker, localCfg.Auth, localCfg.Origin)
+		if err != nil {
+			log.Fatalf("couldn't create new issue tracker: %s\n", err)
+		}
+
+		err = authmanager.AcquireToken(ctx, localCfg, tracker)
+		if err != nil {
+			log.Fatalf("couldn't acquire token from config: %s\n", err)
+		}
+
+		exitOnValidationErrors(validation.Validate(localCfg, tracker))
+
+		statusFetcher, statusCache, err := newStatusFetcher(tracker, localCfg.Origin, localCfg.StatusMapping, localCfg, *noCache, refreshStatusCache)
+		if err != nil {
+			log.Fatalf("couldn't load task status cache: %s\n", err)
+		}
+
+		statusCaches = appendCache(statusCaches, statusCache)
+		namedFetchers := map[string]fetcher.StatusFetcher{}
+		for _, namedCfg := range localCfg.IssueTrackers {
+			namedTracker, err := factory.NewIssueTrackerFrom(ctx, namedCfg.IssueTracker, namedCfg.Auth, namedCfg.Origin)
+			if err != nil {
+				log.Fatalf("couldn't create issue tracker %s: %s\n", namedCfg.Name, err)
+			}
+
+			err = authmanager.AcquireNamedToken(ctx, namedCfg, namedTracker)
+			if err != nil {
+				log.Fatalf("couldn't acquire token for issue tracker %s: %s\n", namedCfg.Name, err)
+			}
+
+			namedFetchers[namedCfg.Name], statusCache, err = newStatusFetcher(
+				namedTracker, namedCfg.Origin, localCfg.StatusMappingFor(namedCfg), localCfg, *noCache, refreshStatusCache,
+			)
+			if err != nil {
+				log.Fatalf("couldn't load task status cache: %s\n", err)
+			}
+
+			statusCaches = appendCache(statusCaches, statusCache)
+		}
+
+		// with multiple issue trackers, each one's concurrency is limited separately & the pool runs enough lookups for all of them
+		concurrency := localCfg.Concurrency
+		if len(namedFetchers) > 0 {
+			for _, namedCfg := range localCfg.IssueTrackers {
+				namedFetchers[namedCfg.Name] = fetcher.NewLimiter(namedFetchers[namedCfg.Name], namedCfg.Concurrency)
+				concurrency += namedCfg.Concurrency
+			}
+
+			statusFetcher = fetcher.NewRouter(fetcher.NewLimiter(statusFetcher, localCfg.Concurrency), namedFetchers)
+		}
+
+		f := fetcher.NewPool(statusFetcher, concurrency)
+		traverser = todoerrs.NewTraverser(f, localCfg, now, callback)
+	}
+
+	if isDiff {
+		changes, err := loadChanges(ctx, *diffBase, *diffFile, *basepath)
+		if err != nil {
+			log.Fatalf("couldn't load changes: %s\n", err)
+		}
+
+		traverser.LimitToChanges(changes)
+	}
+
+	if *staged {
+		index, err := diff.StagedIndex(ctx, *basepath)
+		if err != nil {
+			log.Fatalf("couldn't load staged files: %s\n", err)
+		}
+
+		traverser.ReadStaged(index)
+	}
+
+	if isWatchCommand {
+		session.run(ctx, traverser, watcher.New(paths, *basepath, localCfg.IgnoredPaths), *pollInterval, *refreshInterval)
+	} else if isLSPCommand {
+		if err = lspServer.Serve(ctx, traverser, *refreshInterval); err != nil && ctx.Err() == nil {
+			log.Fatalf("lsp server stopped: %s\n", err)
+		}
+	} else if *stdin {
+		err = traverser.TraverseContent(ctx, *stdinFilename, stdinContent)
+	} else {
+		err = traverser.TraversePath(ctx, paths...)
+	}
+
+	if err != nil && ctx.Err() == nil {
 		log.Fatalf("couldn't traverse basepath: %s", err)
 	}
 
-	if len(todoErrs) > 0 {
-		err = printTodoErrs(todoErrs, *format)
+	// the statuses of the listed todos' issues are fetched before saving the status cache, so that they're cached as well
+	var listed []*listedTodo
+	if isListCommand {
+		listed = listTodos(ctx, traverser, filter)
+	}
+
+	for _, statusCache := range statusCaches {
+		if err := statusCache.Save(); err != nil {
+			log.Printf("couldn't save task status cache: %s\n", err)
+		}
+	}
+
+	if isWatchCommand || isLSPCommand {
+		return
+	} else if isListCommand {
+		if printErr := printTodoList(listed, *format); printErr != nil {
+			panic(printErr)
+		}
+
+		if err != nil {
+			log.Fatalf("todocheck was interrupted & only listed the todos found so far: %s\n", err)
+		}
+
+		return
+	} else if isBaselineCommand {
+		writeBaseline(todoErrs, *baselineFile, *basepath, localCfg, err)
+		return
+	}
+
+	// errors of issues, whose status couldn't be fetched, are not found, so pruning would remove them from the baseline
+	if failed := traverser.Summary().FailedLookups; *pruneBaseline && err == nil && failed > 0 {
+		log.Printf("couldn't prune baseline as the status of %s couldn't be fetched\n", pluralize(failed, "issue"))
+	} else if *pruneBaseline && err == nil {
+		if pruned := todoBaseline.Prune(); pruned > 0 {
+			if err := todoBaseline.Save(localCfg.Baseline); err != nil {
+				log.Fatalf("couldn't prune baseline: %s\n", err)
+			}
+
+			logger.Infof("Pruned %s from the baseline\n", pluralize(pruned, "fixed error"))
+		}
+	}
+
+	// at this point, a traversal error means that the run was interrupted or timed out
+	if len(todoErrs) > 0 || err != nil {
+		if printErr := printTodoErrs(todoErrs, *format); printErr != nil {
+			panic(printErr)
+		}
+	}
+
+	printSummary(traverser.Summary(), todoErrs, baselined, *format)
+	if errors.Is(err, context.DeadlineExceeded) {
+		log.Fatalf("todocheck timed out after %s & only reported the errors found so far: %s\n", *timeout, err)
+	} else if err != nil {
+		log.Fatalf("todocheck was interrupted & only reported the errors found so far: %s\n", err)
+	}
+
+	for _, todoErr := range todoErrs {
+		if !todoErr.IsWarning() {
+			os.Exit(2)
+		}
+	}
+}
+
+// installHook installs todocheck as a pre-commit hook in the git repository, which contains the basepath
+func installHook(args []string) {
+	fs := flag.NewFlagSet("install-hook", flag.ExitOnError)
+	var basepath = fs.String("basepath", ".", "A path in the git repository to install the hook in. Defaults to current directory")
+	if err := fs.Parse(args); err != nil {
+		log.Fatal(err)
+	}
+
+	if args := fs.Args(); len(args) > 0 {
+		log.Fatalf("Unexpected arguments: %s\n", args)
+	}
+
+	filename, err := githook.Install(context.Background(), *basepath)
+	if err != nil {
+		log.Fatalf("couldn't install pre-commit hook: %s\n", err)
+	}
+
+	fmt.Printf("Installed pre-commit hook %s\n", filename)
+}
+
+// readFilesFrom the given list of files & directories, one per line, which is read from stdin if it's -. Empty lines are skipped
+func readFilesFrom(filename string) ([]string, error) {
+	r := io.Reader(os.Stdin)
+	if filename != "-" {
+		f, err := os.Open(filename)
 		if err != nil {
-			panic(err)
+			return nil, fmt.Errorf("couldn't open %s: %w", filename, err)
+		}
+		defer f.Close()
+
+		r = f
+	}
+
+	var paths []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		if path := strings.TrimSpace(scanner.Text()); path != "" {
+			paths = append(paths, path)
 		}
-		os.Exit(2)
 	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("couldn't read %s: %w", filename, err)
+	}
+
+	return paths, nil
+}
+
+// loadChanges relative to the given git ref or from the given unified diff file, which is read from stdin if it's -
+func loadChanges(ctx context.Context, diffBase, diffFile, basepath string) (*diff.Changes, error) {
+	if diffBase != "" {
+		return diff.FromGit(ctx, basepath, diffBase)
+	}
+
+	if diffFile == "-" {
+		return diff.Parse(os.Stdin, basepath)
+	}
+
+	f, err := os.Open(diffFile)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't open diff file: %w", err)
+	}
+	defer f.Close()
+
+	return diff.Parse(f, basepath)
+}
+
+// writeBaseline of the given todo errors to filename, which defaults to the configured baseline.
+// Warnings are not recorded. If the traversal was stopped early, the baseline is not written, as it would be incomplete
+func writeBaseline(todoErrs []*todocheckerrors.TODO, filename, basepath string, cfg *config.Local, traverseErr error) {
+	if traverseErr != nil {
+		log.Fatalf("couldn't write baseline as not all todos were checked: %s\n", traverseErr)
+	}
+
+	if filename == "" {
+		filename = cfg.Baseline
+	}
+
+	if filename == "" {
+		filename = filepath.Join(basepath, baseline.DefaultFile)
+	}
+
+	b := baseline.New(basepath)
+	for _, todoErr := range todoErrs {
+		if !todoErr.IsWarning() {
+			b.Add(todoErr)
+		}
+	}
+
+	if err := b.Save(filename); err != nil {
+		log.Fatalf("couldn't write baseline: %s\n", err)
+	}
+
+	fmt.Printf("Wrote %s to baseline %s\n", pluralize(b.Len(), "error"), filename)
+}
+
+// newStatusFetcher for the given issue tracker, which interprets its statuses with the given status mapping.
+// Fetched task statuses are cached for the tracker's origin, unless noCache is set, in which case the returned cache is nil
+func newStatusFetcher(
+	tracker issuetracker.IssueTracker, origin string, statusMapping config.StatusMapping, cfg *config.Local, noCache, refreshCache bool,
+) (fetcher.StatusFetcher, *cache.Cache, error) {
+	statusFetcher := fetcher.NewFetcher(tracker, statusMapping, cfg)
+	if noCache {
+		return statusFetcher, nil, nil
+	}
+
+	statusCache, err := cache.New(statusFetcher, origin, statusMapping, cfg.Cache, refreshCache)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return statusCache, statusCache, nil
+}
+
+func appendCache(caches []*cache.Cache, c *cache.Cache) []*cache.Cache {
+	if c == nil {
+		return caches
+	}
+
+	return append(caches, c)
+}
+
+// parseNow parses the time todo expiry dates are checked against.
+// It is either a date, which is interpreted as the start of the day in the local time zone, or an RFC3339 time
+func parseNow(value string) (time.Time, error) {
+	if value == "" {
+		return time.Now(), nil
+	}
+
+	if now, err := time.ParseInLocation("2006-01-02", value, time.Local); err == nil {
+		return now, nil
+	}
+
+	now, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("expected a date in the format 2006-01-02 or an RFC3339 time, got %q", value)
+	}
+
+	return now, nil
+}
+
+func exitOnValidationErrors(errs []error) {
+	if len(errs) == 0 {
+		return
+	}
+
+	for _, err := range errs {
+		log.Println(err)
+	}
+
+	os.Exit(1)
+}
+
+// printSummary of the checked todos & the amount of errors, which were not reported as they are in the baseline.
+// For json output, it is printed to stderr, so that stdout remains valid json
+func printSummary(summary todoerrs.Summary, errs []*todocheckerrors.TODO, baselined int, format string) {
+	warnings := 0
+	for _, err := range errs {
+		if err.IsWarning() {
+			warnings++
+		}
+	}
+
+	out := color.Output
+	if format == "json" {
+		out = color.Error
+	}
+
+	msg := fmt.Sprintf("Checked %s: found %s & %s",
+		pluralize(summary.Todos, "todo"), pluralize(len(errs)-warnings, "error"), pluralize(warnings, "warning"))
+	if summary.FailedLookups > 0 {
+		msg += fmt.Sprintf(". Failed to look up %s", pluralize(summary.FailedLookups, "issue"))
+	}
+
+	if baselined > 0 {
+		msg += fmt.Sprintf(". Skipped %s in the baseline", pluralize(baselined, "error"))
+	}
+
+	fmt.Fprintln(out, msg)
+}
+
+func pluralize(count int, noun string) string {
+	if count == 1 {
+		return fmt.Sprintf("%d %s", count, noun)
+	}
+
+	return fmt.Sprintf("%d %ss", count, noun)
 }
 
 func printTodoErrs(errs []*todocheckerrors.TODO, format string) error {
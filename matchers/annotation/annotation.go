@@ -0,0 +1,72 @@
+// Package annotation contains the annotation of a valid todo, which is shared among all todo matchers.
+// The annotation follows the todo keyword, e.g. `(alice) J123, J321 (until 2026-12-31):` in `// TODO(alice) J123, J321 (until 2026-12-31): fix this`
+package annotation
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// DateLayout of a todo's expiry date
+const DateLayout = "2006-01-02"
+
+// Pattern of a valid todo's annotation. The optional owner, the comma-separated issue references & the optional expiry date
+// are captured in groups named "owner", "refs" & "until"
+const Pattern = `(?:\((?P<owner>[^()\s]+)\))? (?P<refs>` + issueRefPattern + `(?:,[ \t]*` + issueRefPattern + `)*)` +
+	`(?: \(until (?P<until>[0-9]{4}-[0-9]{2}-[0-9]{2})\))?:.*`
+
+// issueRefPattern matches a single issue reference, which is either a task ID, a full issue URL
+// or a reference to an issue in another repository, e.g. owner/repo#12.
+// Task IDs & references to other repositories can be prefixed with the name of the issue tracker they belong to, e.g. gh:#12
+const issueRefPattern = `(?:https?://[a-zA-Z0-9.\-]+(?::[0-9]+)?(?:/[^\s,:()]*)?|` +
+	`(?:[a-zA-Z][a-zA-Z0-9_\-]*:)?(?:[\w.\-]+(?:/[\w.\-]+)+#[0-9]+|#?[a-zA-Z0-9\-]+))`
+
+// ExtractIssueRefs from the given expression, using the first of the valid todo patterns, which matches it.
+// Nil is returned if none of them matches
+func ExtractIssueRefs(expr string, patterns ...*regexp.Regexp) []string {
+	refs := extractGroup(expr, "refs", patterns)
+	if refs == "" {
+		return nil
+	}
+
+	var res []string
+	for _, ref := range strings.Split(refs, ",") {
+		res = append(res, strings.TrimSpace(ref))
+	}
+
+	return res
+}
+
+// ExtractOwner from the given expression, using the first of the valid todo patterns, which matches it.
+// If the todo doesn't have an owner, an empty string is returned
+func ExtractOwner(expr string, patterns ...*regexp.Regexp) string {
+	return extractGroup(expr, "owner", patterns)
+}
+
+// ExtractExpiry from the given expression, using the first of the valid todo patterns, which matches it.
+// If the todo doesn't have an expiry date, nil is returned
+func ExtractExpiry(expr string, patterns ...*regexp.Regexp) (*time.Time, error) {
+	until := extractGroup(expr, "until", patterns)
+	if until == "" {
+		return nil, nil
+	}
+
+	date, err := time.ParseInLocation(DateLayout, until, time.Local)
+	if err != nil {
+		return nil, fmt.Errorf("invalid expiry date %s: %w", until, err)
+	}
+
+	return &date, nil
+}
+
+func extractGroup(expr, group string, patterns []*regexp.Regexp) string {
+	for _, pattern := range patterns {
+		if res := pattern.FindStringSubmatch(expr); res != nil {
+			return res[pattern.SubexpIndex(group)]
+		}
+	}
+
+	return ""
+}
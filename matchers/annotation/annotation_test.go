@@ -0,0 +1,71 @@
+package annotation
+
+import (
+	"reflect"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestExtractExpiry(t *testing.T) {
+	pattern := regexp.MustCompile(`^\s*// TODO` + Pattern)
+	tests := []struct {
+		expr    string
+		want    *time.Time
+		wantErr bool
+	}{
+		{"// TODO J123: fix this", nil, false},
+		{"// TODO #123 (until 2026-12-31): fix this", date(2026, 12, 31), false},
+		{"// TODO #123 (until 2026-02-30): fix this", nil, true},
+		{"// TODO J123: fix this (until 2026-12-31): not an expiry date", nil, false},
+	}
+
+	for _, tt := range tests {
+		res, err := ExtractExpiry(tt.expr, pattern)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("got error %v for %q, want error: %v", err, tt.expr, tt.wantErr)
+		}
+		if (res == nil) != (tt.want == nil) || (res != nil && !res.Equal(*tt.want)) {
+			t.Errorf("got expiry %v for %q, want %v", res, tt.expr, tt.want)
+		}
+	}
+}
+
+func TestExtractOwnerAndIssueRefs(t *testing.T) {
+	pattern := regexp.MustCompile(`^\s*// TODO` + Pattern)
+	tests := []struct {
+		expr      string
+		wantOwner string
+		wantRefs  []string
+	}{
+		{"// TODO J123: fix this", "", []string{"J123"}},
+		{"// TODO(alice) #123: fix this", "alice", []string{"#123"}},
+		{"// TODO(@alice) J-123 (until 2026-12-31): fix this", "@alice", []string{"J-123"}},
+		{"// TODO #12, #34: remove once both land", "", []string{"#12", "#34"}},
+		{"// TODO(alice) J-1,J-2,  J-3 (until 2026-12-31): fix this", "alice", []string{"J-1", "J-2", "J-3"}},
+		{"// TODO #12,: fix this", "", nil},
+		{"// TODO https://github.com/org/repo/issues/12: fix this", "", []string{"https://github.com/org/repo/issues/12"}},
+		{"// TODO(alice) https://jira.example.com:8080/browse/ABC-12, #3: fix this", "alice", []string{"https://jira.example.com:8080/browse/ABC-12", "#3"}},
+		{"// TODO https://github.com/org/repo/issues/12 (until 2026-12-31): fix this", "", []string{"https://github.com/org/repo/issues/12"}},
+		{"// TODO org/libB#77, #3: bump after fix", "", []string{"org/libB#77", "#3"}},
+		{"// TODO(alice) group/sub.group/project#5: fix this", "alice", []string{"group/sub.group/project#5"}},
+		{"// TODO gh:#12, jira:ABC-12: fix this", "", []string{"gh:#12", "jira:ABC-12"}},
+		{"// TODO(alice) gh:org/lib#3 (until 2026-12-31): fix this", "alice", []string{"gh:org/lib#3"}},
+		{"// TODO J-123: fix: this", "", []string{"J-123"}},
+		{"// TODO(alice bob) #123: fix this", "", nil},
+	}
+
+	for _, tt := range tests {
+		if owner := ExtractOwner(tt.expr, pattern); owner != tt.wantOwner {
+			t.Errorf("got owner %q for %q, want %q", owner, tt.expr, tt.wantOwner)
+		}
+		if refs := ExtractIssueRefs(tt.expr, pattern); !reflect.DeepEqual(refs, tt.wantRefs) {
+			t.Errorf("got issue refs %q for %q, want %q", refs, tt.expr, tt.wantRefs)
+		}
+	}
+}
+
+func date(year int, month time.Month, day int) *time.Time {
+	d := time.Date(year, month, day, 0, 0, 0, 0, time.Local)
+	return &d
+}
@@ -2,6 +2,7 @@ package caseinsensitive
 
 import (
 	"regexp"
+	"time"
 
 	"github.com/preslavmihaylov/todocheck/matchers"
 )
@@ -24,8 +25,16 @@ func (m *TodoMatcher) IsValid(expr string) bool {
 	return m.matcher.IsValid(todoToUpper(expr))
 }
 
-func (m *TodoMatcher) ExtractIssueRef(expr string) (string, error) {
-	return m.matcher.ExtractIssueRef(todoToUpper(expr))
+func (m *TodoMatcher) ExtractIssueRefs(expr string) ([]string, error) {
+	return m.matcher.ExtractIssueRefs(todoToUpper(expr))
+}
+
+func (m *TodoMatcher) ExtractExpiry(expr string) (*time.Time, error) {
+	return m.matcher.ExtractExpiry(todoToUpper(expr))
+}
+
+func (m *TodoMatcher) ExtractOwner(expr string) (string, error) {
+	return m.matcher.ExtractOwner(todoToUpper(expr))
 }
 
 func todoToUpper(expr string) string {
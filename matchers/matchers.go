@@ -2,7 +2,9 @@ package matchers
 
 import (
 	"path/filepath"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/preslavmihaylov/todocheck/matchers/groovy"
 	"github.com/preslavmihaylov/todocheck/matchers/nim"
@@ -19,7 +21,9 @@ import (
 type TodoMatcher interface {
 	IsMatch(expr string) bool
 	IsValid(expr string) bool
-	ExtractIssueRef(expr string) (string, error)
+	ExtractIssueRefs(expr string) ([]string, error)
+	ExtractExpiry(expr string) (*time.Time, error)
+	ExtractOwner(expr string) (string, error)
 }
 
 // CommentMatcher is used to match comments for various filetypes & comment-types.
@@ -38,145 +42,96 @@ type matcherFactory struct {
 
 var (
 	standardMatcherFactory = &matcherFactory{
-		func() func([]string) TodoMatcher {
-			var once sync.Once
-			var matcher TodoMatcher
-			return func(customTodos []string) TodoMatcher {
-				once.Do(func() {
-					matcher = standard.NewTodoMatcher(customTodos)
-				})
-				return matcher
-			}
-		}(),
+		cachedTodoMatcher(func(customTodos []string) TodoMatcher {
+			return standard.NewTodoMatcher(customTodos)
+		}),
 		func(callback state.CommentCallback) CommentMatcher {
 			return standard.NewCommentMatcher(callback, false)
 		},
 	}
 	standardMatcherWithNestedMultilineCommentsFactory = &matcherFactory{
-		func() func([]string) TodoMatcher {
-			var once sync.Once
-			var matcher TodoMatcher
-			return func(customTodos []string) TodoMatcher {
-				once.Do(func() {
-					matcher = standard.NewTodoMatcher(customTodos)
-				})
-				return matcher
-			}
-		}(),
+		cachedTodoMatcher(func(customTodos []string) TodoMatcher {
+			return standard.NewTodoMatcher(customTodos)
+		}),
 		func(callback state.CommentCallback) CommentMatcher {
 			return standard.NewCommentMatcher(callback, true)
 		},
 	}
 	scriptsMatcherFactory = &matcherFactory{
-		func() func([]string) TodoMatcher {
-			var once sync.Once
-			var matcher TodoMatcher
-			return func(customTodos []string) TodoMatcher {
-				once.Do(func() {
-					matcher = scripts.NewTodoMatcher(customTodos)
-				})
-				return matcher
-			}
-		}(),
+		cachedTodoMatcher(func(customTodos []string) TodoMatcher {
+			return scripts.NewTodoMatcher(customTodos)
+		}),
 		func(callback state.CommentCallback) CommentMatcher {
 			return scripts.NewCommentMatcher(callback)
 		},
 	}
 	phpMatcherFactory = &matcherFactory{
-		func() func([]string) TodoMatcher {
-			var once sync.Once
-			var matcher TodoMatcher
-			return func(customTodos []string) TodoMatcher {
-				once.Do(func() {
-					matcher = php.NewTodoMatcher(customTodos)
-				})
-				return matcher
-			}
-		}(),
+		cachedTodoMatcher(func(customTodos []string) TodoMatcher {
+			return php.NewTodoMatcher(customTodos)
+		}),
 		func(callback state.CommentCallback) CommentMatcher {
 			return php.NewCommentMatcher(callback)
 		},
 	}
 	pythonMatcherFactory = &matcherFactory{
-		func() func([]string) TodoMatcher {
-			var once sync.Once
-			var matcher TodoMatcher
-			return func(customTodos []string) TodoMatcher {
-				once.Do(func() {
-					matcher = python.NewTodoMatcher(customTodos)
-				})
-				return matcher
-			}
-		}(),
+		cachedTodoMatcher(func(customTodos []string) TodoMatcher {
+			return python.NewTodoMatcher(customTodos)
+		}),
 		func(callback state.CommentCallback) CommentMatcher {
 			return python.NewCommentMatcher(callback)
 		},
 	}
 	groovyMatcherFactory = &matcherFactory{
-		func() func([]string) TodoMatcher {
-			var once sync.Once
-			var matcher TodoMatcher
-			return func(customTodos []string) TodoMatcher {
-				once.Do(func() {
-					matcher = groovy.NewTodoMatcher(customTodos)
-				})
-				return matcher
-			}
-		}(),
+		cachedTodoMatcher(func(customTodos []string) TodoMatcher {
+			return groovy.NewTodoMatcher(customTodos)
+		}),
 		func(callback state.CommentCallback) CommentMatcher {
 			return groovy.NewCommentMatcher(callback)
 		},
 	}
 	vueMatcherFactory = &matcherFactory{
-		func() func([]string) TodoMatcher {
-			var once sync.Once
-			var matcher TodoMatcher
-
-			return func(customTodos []string) TodoMatcher {
-				once.Do(func() {
-					matcher = vue.NewTodoMatcher(customTodos)
-				})
-				return matcher
-			}
-		}(),
+		cachedTodoMatcher(func(customTodos []string) TodoMatcher {
+			return vue.NewTodoMatcher(customTodos)
+		}),
 		func(callback state.CommentCallback) CommentMatcher {
 			return vue.NewCommentMatcher(callback)
 		},
 	}
 	nimMatcherFactory = &matcherFactory{
-		func() func([]string) TodoMatcher {
-			var once sync.Once
-			var matcher TodoMatcher
-
-			return func(customTodos []string) TodoMatcher {
-				once.Do(func() {
-					matcher = nim.NewTodoMatcher(customTodos)
-				})
-				return matcher
-			}
-		}(),
+		cachedTodoMatcher(func(customTodos []string) TodoMatcher {
+			return nim.NewTodoMatcher(customTodos)
+		}),
 		func(callback state.CommentCallback) CommentMatcher {
 			return nim.NewCommentMatcher(callback)
 		},
 	}
 	twigMatcherFactory = &matcherFactory{
-		func() func([]string) TodoMatcher {
-			var once sync.Once
-			var matcher TodoMatcher
-
-			return func(customTodos []string) TodoMatcher {
-				once.Do(func() {
-					matcher = twig.NewTodoMatcher(customTodos)
-				})
-				return matcher
-			}
-		}(),
+		cachedTodoMatcher(func(customTodos []string) TodoMatcher {
+			return twig.NewTodoMatcher(customTodos)
+		}),
 		func(callback state.CommentCallback) CommentMatcher {
 			return twig.NewCommentMatcher(callback)
 		},
 	}
 )
 
+// cachedTodoMatcher returns a function, which creates a todo matcher via newTodoMatcher once per set of custom todos
+func cachedTodoMatcher(newTodoMatcher func([]string) TodoMatcher) func([]string) TodoMatcher {
+	var mu sync.Mutex
+	matchers := map[string]TodoMatcher{}
+	return func(customTodos []string) TodoMatcher {
+		mu.Lock()
+		defer mu.Unlock()
+
+		key := strings.Join(customTodos, "\x00")
+		if _, ok := matchers[key]; !ok {
+			matchers[key] = newTodoMatcher(customTodos)
+		}
+
+		return matchers[key]
+	}
+}
+
 var supportedMatchers = map[string]*matcherFactory{
 	// file types, supporting standard comments
 	".go":   standardMatcherFactory,
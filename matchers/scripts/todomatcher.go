@@ -2,8 +2,10 @@ package scripts
 
 import (
 	"regexp"
+	"time"
 
 	"github.com/preslavmihaylov/todocheck/common"
+	"github.com/preslavmihaylov/todocheck/matchers/annotation"
 	"github.com/preslavmihaylov/todocheck/matchers/errors"
 )
 
@@ -13,7 +15,7 @@ func NewTodoMatcher(todos []string) *TodoMatcher {
 
 	// Single line
 	singleLineTodoPattern := regexp.MustCompile(`^\s*#.*` + pattern)
-	singleLineValidTodoPattern := regexp.MustCompile(`^\s*# ` + pattern + ` (#?[a-zA-Z0-9\-]+):.*`)
+	singleLineValidTodoPattern := regexp.MustCompile(`^\s*# ` + pattern + annotation.Pattern)
 
 	return &TodoMatcher{
 		singleLineTodoPattern:      singleLineTodoPattern,
@@ -37,17 +39,32 @@ func (m *TodoMatcher) IsValid(expr string) bool {
 	return m.singleLineValidTodoPattern.Match([]byte(expr))
 }
 
-// ExtractIssueRef from the given expression.
+// ExtractIssueRefs from the given expression.
 // If the expression is invalid, an ErrInvalidTODO is returned
-func (m *TodoMatcher) ExtractIssueRef(expr string) (string, error) {
+func (m *TodoMatcher) ExtractIssueRefs(expr string) ([]string, error) {
 	if !m.IsValid(expr) {
-		return "", errors.ErrInvalidTODO
+		return nil, errors.ErrInvalidTODO
+	}
+
+	return annotation.ExtractIssueRefs(expr, m.singleLineValidTodoPattern), nil
+}
+
+// ExtractExpiry date from the given expression. If the todo doesn't expire, nil is returned.
+// If the expression is invalid, an ErrInvalidTODO is returned
+func (m *TodoMatcher) ExtractExpiry(expr string) (*time.Time, error) {
+	if !m.IsValid(expr) {
+		return nil, errors.ErrInvalidTODO
 	}
 
-	singleLineRes := m.singleLineValidTodoPattern.FindStringSubmatch(expr)
-	if len(singleLineRes) >= 2 {
-		return singleLineRes[1], nil
+	return annotation.ExtractExpiry(expr, m.singleLineValidTodoPattern)
+}
+
+// ExtractOwner of the todo from the given expression. If the todo doesn't have an owner, an empty string is returned.
+// If the expression is invalid, an ErrInvalidTODO is returned
+func (m *TodoMatcher) ExtractOwner(expr string) (string, error) {
+	if !m.IsValid(expr) {
+		return "", errors.ErrInvalidTODO
 	}
 
-	panic("Invariant violated. No issue reference found in valid TODO")
+	return annotation.ExtractOwner(expr, m.singleLineValidTodoPattern), nil
 }
@@ -0,0 +1,95 @@
+// Package suppression contains the directives, which suppress todo errors & are shared among all comment types,
+// e.g. `// todocheck:ignore-next-line (until 2026-12-31): waiting for the upstream fix`
+package suppression
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/preslavmihaylov/todocheck/matchers/annotation"
+)
+
+// Kind of a suppression directive
+type Kind string
+
+// supported suppression directive kinds enum
+const (
+	// IgnoreNextLine suppresses the errors of todos on the line after the directive's comment
+	IgnoreNextLine Kind = "ignore-next-line"
+
+	// Ignore suppresses the errors of todos on the directive's line
+	Ignore Kind = "ignore"
+
+	// Disable suppresses the errors of all todos until the next Enable directive or the end of the file
+	Disable Kind = "disable"
+
+	// Enable ends the suppression of the previous Disable directive
+	Enable Kind = "enable"
+)
+
+// directivePattern matches a suppression directive with its optional expiry date & reason,
+// captured in groups named "kind", "until" & "reason"
+var directivePattern = regexp.MustCompile(`todocheck:(?P<kind>ignore-next-line|ignore|disable|enable)\b` +
+	`(?: \(until (?P<until>[0-9]{4}-[0-9]{2}-[0-9]{2})\))?(?::(?P<reason>[^\n]*))?`)
+
+// commentTerminators, which are trimmed from the end of a directive's reason
+var commentTerminators = []string{"*/", "-->", "#}", "?>", "#]"}
+
+// Directive to suppress todo errors
+type Directive struct {
+	Kind Kind
+
+	// Until is the date, after which the directive no longer suppresses todo errors. It is nil if the directive doesn't expire
+	Until  *time.Time
+	Reason string
+}
+
+// IsExpired returns true if the directive's expiry date is before now. Directives are valid until the end of their expiry date
+func (d *Directive) IsExpired(now time.Time) bool {
+	return d.Until != nil && !now.Before(d.Until.AddDate(0, 0, 1))
+}
+
+func (d *Directive) String() string {
+	return "todocheck:" + string(d.Kind)
+}
+
+// Parse the suppression directives in the given comment.
+// If any of them has an invalid expiry date, the directives parsed before it are returned along with an error
+func Parse(comment string) ([]*Directive, error) {
+	var directives []*Directive
+	for _, match := range directivePattern.FindAllStringSubmatch(comment, -1) {
+		d := &Directive{
+			Kind:   Kind(match[directivePattern.SubexpIndex("kind")]),
+			Reason: trimReason(match[directivePattern.SubexpIndex("reason")]),
+		}
+
+		if until := match[directivePattern.SubexpIndex("until")]; until != "" {
+			date, err := time.ParseInLocation(annotation.DateLayout, until, time.Local)
+			if err != nil {
+				return directives, fmt.Errorf("invalid expiry date %s of %s: %w", until, d, err)
+			}
+
+			d.Until = &date
+		}
+
+		directives = append(directives, d)
+	}
+
+	return directives, nil
+}
+
+// Strip the suppression directives from the given comment, so that the rest of it can be matched as a todo
+func Strip(comment string) string {
+	return directivePattern.ReplaceAllString(comment, "")
+}
+
+func trimReason(reason string) string {
+	reason = strings.TrimSpace(reason)
+	for _, terminator := range commentTerminators {
+		reason = strings.TrimSpace(strings.TrimSuffix(reason, terminator))
+	}
+
+	return reason
+}
@@ -0,0 +1,59 @@
+package suppression
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	until := time.Date(2026, 12, 31, 0, 0, 0, 0, time.Local)
+	tests := []struct {
+		comment string
+		want    []*Directive
+		wantErr bool
+	}{
+		{"// TODO J123: fix this", nil, false},
+		{"// todocheck:ignore-next-line", []*Directive{{Kind: IgnoreNextLine}}, false},
+		{"// TODO: fix this todocheck:ignore", []*Directive{{Kind: Ignore}}, false},
+		{"# todocheck:disable: legacy code", []*Directive{{Kind: Disable, Reason: "legacy code"}}, false},
+		{"/* todocheck:enable */", []*Directive{{Kind: Enable}}, false},
+		{
+			"<!-- todocheck:ignore (until 2026-12-31): waiting for the upstream fix -->",
+			[]*Directive{{Kind: Ignore, Until: &until, Reason: "waiting for the upstream fix"}},
+			false,
+		},
+		{"// todocheck:ignored", nil, false},
+		{"// todocheck:ignore (until 2026-02-30)", nil, true},
+	}
+
+	for _, tt := range tests {
+		directives, err := Parse(tt.comment)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("got error %v for %q, want error: %v", err, tt.comment, tt.wantErr)
+		}
+		if !reflect.DeepEqual(directives, tt.want) {
+			t.Errorf("got directives %v for %q, want %v", directives, tt.comment, tt.want)
+		}
+	}
+}
+
+func TestIsExpired(t *testing.T) {
+	until := time.Date(2026, 6, 14, 0, 0, 0, 0, time.Local)
+	d := &Directive{Kind: Ignore, Until: &until}
+	if d.IsExpired(time.Date(2026, 6, 14, 23, 0, 0, 0, time.Local)) {
+		t.Errorf("Expected directive to be valid until the end of its expiry date")
+	}
+	if !d.IsExpired(time.Date(2026, 6, 15, 0, 0, 0, 0, time.Local)) {
+		t.Errorf("Expected directive to be expired after its expiry date")
+	}
+	if (&Directive{Kind: Ignore}).IsExpired(time.Now()) {
+		t.Errorf("Expected directive without an expiry date to never expire")
+	}
+}
+
+func TestStrip(t *testing.T) {
+	if got := Strip("// TODO J123: fix this todocheck:ignore: known issue"); got != "// TODO J123: fix this " {
+		t.Errorf("got %q after stripping directives", got)
+	}
+}
@@ -23,25 +23,35 @@ type validateFunc func() error
 // TodocheckScenario encapsulates the scenario the program is expected to execute.
 // This let's you specify what are the program inputs & what is the expected outputs.
 type TodocheckScenario struct {
-	binaryLoc              string
-	basepath               string
-	cfgPath                string
-	testCfgPath            string
-	cfg                    *config.Local
-	expectedAuthToken      string
-	userOfflineToken       string
-	gitOriginURL           string
-	authTokenEnvVariable   string
-	versionFlagRequested   bool
-	onlyRunOnCI            bool
-	deleteTokensCacheAfter bool
-	expectedExitCode       int
-	expectJSONFormat       bool
-	expectedOutputText     string
-	issueTracker           issuetracker.Type
-	issues                 map[string]issuetracker.Status
-	envVariables           map[string]string
-	todoErrScenarios       []*TodoErrScenario
+	binaryLoc                string
+	command                  []string
+	basepath                 string
+	cfgPath                  string
+	testCfgPath              string
+	cfg                      *config.Local
+	expectedAuthToken        string
+	userOfflineToken         string
+	gitOriginURL             string
+	authTokenEnvVariable     string
+	versionFlagRequested     bool
+	offlineFlagRequested     bool
+	now                      string
+	reportUnusedSuppressions bool
+	diffFile                 string
+	paths                    []string
+	stdinFilename            string
+	stdinContent             string
+	onlyRunOnCI              bool
+	deleteTokensCacheAfter   bool
+	expectedExitCode         int
+	expectJSONFormat         bool
+	expectedOutputText       string
+	issueTracker             issuetracker.Type
+	issues                   map[string]issuetracker.Status
+	movedIssues              map[string]string
+	assignees                map[string]string
+	envVariables             map[string]string
+	todoErrScenarios         []*TodoErrScenario
 }
 
 // NewScenario to execute against the todocheck program
@@ -50,6 +60,8 @@ func NewScenario() *TodocheckScenario {
 		binaryLoc:        "./todocheck",
 		basepath:         ".",
 		issues:           map[string]issuetracker.Status{},
+		movedIssues:      map[string]string{},
+		assignees:        map[string]string{},
 		envVariables:     map[string]string{},
 		expectedExitCode: 0,
 	}
@@ -61,6 +73,12 @@ func (s *TodocheckScenario) WithBinary(binaryLoc string) *TodocheckScenario {
 	return s
 }
 
+// WithCommand runs the given subcommand of the todocheck binary with the given flags, e.g. list --issue J123
+func (s *TodocheckScenario) WithCommand(command string, flags ...string) *TodocheckScenario {
+	s.command = append([]string{command}, flags...)
+	return s
+}
+
 // WithBasepath let's you specify the --basepath flag passed to the program
 func (s *TodocheckScenario) WithBasepath(basepath string) *TodocheckScenario {
 	s.basepath = basepath
@@ -91,6 +109,43 @@ func (s *TodocheckScenario) WithVersionFlag() *TodocheckScenario {
 	return s
 }
 
+// WithOfflineFlag sets the --offline flag when calling the todocheck binary
+func (s *TodocheckScenario) WithOfflineFlag() *TodocheckScenario {
+	s.offlineFlagRequested = true
+	return s
+}
+
+// WithNow sets the --now flag when calling the todocheck binary, so that todo expiry dates are checked against the given date
+func (s *TodocheckScenario) WithNow(now string) *TodocheckScenario {
+	s.now = now
+	return s
+}
+
+// WithReportUnusedSuppressionsFlag sets the --report-unused-suppressions flag when calling the todocheck binary
+func (s *TodocheckScenario) WithReportUnusedSuppressionsFlag() *TodocheckScenario {
+	s.reportUnusedSuppressions = true
+	return s
+}
+
+// WithDiffFile sets the --diff-file flag when calling the todocheck binary, so that only errors on the lines changed in the given diff are reported
+func (s *TodocheckScenario) WithDiffFile(diffFile string) *TodocheckScenario {
+	s.diffFile = diffFile
+	return s
+}
+
+// WithPaths passes the given files & directories as arguments to the todocheck binary, so that only they are checked
+func (s *TodocheckScenario) WithPaths(paths ...string) *TodocheckScenario {
+	s.paths = append(s.paths, paths...)
+	return s
+}
+
+// WithStdin sets the --stdin & --stdin-filename flags when calling the todocheck binary & writes the given content to its stdin
+func (s *TodocheckScenario) WithStdin(filename, content string) *TodocheckScenario {
+	s.stdinFilename = filename
+	s.stdinContent = content
+	return s
+}
+
 // OnlyRunOnCI configures this scenario to only execute when executed in a CI environment.
 // If ran locally, this scenario will succeed unconditionally.
 // This is useful in situations when a certain scenario needs specific data available on the CI environment only
@@ -167,6 +222,21 @@ func (s *TodocheckScenario) WithIssue(issueID string, status issuetracker.Status
 	return s
 }
 
+// WithMovedIssue sets up an open issue, which was moved to another project & is now known by the new issue ID.
+// The mock issue tracker responds with the new issue ID, when the issue is looked up by its old one
+func (s *TodocheckScenario) WithMovedIssue(issueID, newIssueID string) *TodocheckScenario {
+	s.issues[issueID] = issuetracker.StatusOpen
+	s.movedIssues[issueID] = newIssueID
+	return s
+}
+
+// WithAssignedIssue sets up a mock issue in your issue tracker with the given status, which is assigned to the given user
+func (s *TodocheckScenario) WithAssignedIssue(issueID string, status issuetracker.Status, assignee string) *TodocheckScenario {
+	s.issues[issueID] = status
+	s.assignees[issueID] = assignee
+	return s
+}
+
 // SetOfflineTokenWhenRequested by sending the specified token to the program's standard input
 func (s *TodocheckScenario) SetOfflineTokenWhenRequested(token string) *TodocheckScenario {
 	s.userOfflineToken = token
@@ -180,8 +250,12 @@ func (s *TodocheckScenario) DeleteTokensCacheAfter() *TodocheckScenario {
 }
 
 // ExpectTodoErr appends a new todo err scenario to expect from the program execution
+// Warnings don't affect the expected exit code
 func (s *TodocheckScenario) ExpectTodoErr(sc *TodoErrScenario) *TodocheckScenario {
-	s.expectedExitCode = 2
+	if !sc.errType.IsWarning() {
+		s.expectedExitCode = 2
+	}
+
 	s.todoErrScenarios = append(s.todoErrScenarios, sc)
 	return s
 }
@@ -217,11 +291,29 @@ func (s *TodocheckScenario) Run() error {
 		return fmt.Errorf("couldn't initialize todocheck config: %w", err)
 	}
 
-	cmd := exec.Command(s.binaryLoc, "--basepath", s.basepath, "--config", s.cfgPath)
+	// the status cache is disabled as mock issue trackers of different scenarios can share the same origin
+	args := append(append([]string{}, s.command...), "--basepath", s.basepath, "--config", s.cfgPath, "--no-cache")
+	cmd := exec.Command(s.binaryLoc, args...)
 	if s.versionFlagRequested {
 		cmd.Args = append(cmd.Args, "--version")
 	}
 
+	if s.offlineFlagRequested {
+		cmd.Args = append(cmd.Args, "--offline")
+	}
+
+	if s.now != "" {
+		cmd.Args = append(cmd.Args, "--now", s.now)
+	}
+
+	if s.reportUnusedSuppressions {
+		cmd.Args = append(cmd.Args, "--report-unused-suppressions")
+	}
+
+	if s.diffFile != "" {
+		cmd.Args = append(cmd.Args, "--diff-file", s.diffFile)
+	}
+
 	cmd.Env = os.Environ()
 	if s.authTokenEnvVariable != "" {
 		if os.Getenv(s.authTokenEnvVariable) == "" {
@@ -237,6 +329,12 @@ func (s *TodocheckScenario) Run() error {
 		cmd.Args = append(cmd.Args, "--format", format)
 	}
 
+	if s.stdinFilename != "" {
+		cmd.Args = append(cmd.Args, "--stdin", "--stdin-filename", s.stdinFilename)
+	}
+
+	cmd.Args = append(cmd.Args, s.paths...)
+
 	teardown, err := s.setupTestEnvironment(cmd)
 	if err != nil {
 		return fmt.Errorf("couldn't setup test environment: %s", err)
@@ -248,6 +346,10 @@ func (s *TodocheckScenario) Run() error {
 		stdin.Write([]byte(s.userOfflineToken + "\n"))
 	}
 
+	if s.stdinFilename != "" {
+		stdin.Write([]byte(s.stdinContent))
+	}
+
 	cmd.Stdin = &stdin
 	cmd.Stdout = &stdout
 	cmd.Stderr = &stderr
@@ -346,9 +448,29 @@ func (s *TodocheckScenario) setupMockIssueTrackerServer() (teardownFunc, error)
 			return
 		}
 
+		if issuetracker.IsBatchRequest(s.issueTracker, r) {
+			for _, issue := range issuetracker.BatchRequestedIssues(s.issueTracker, r) {
+				if s.issues[issue] == issuetracker.StatusUnavailable {
+					respondUnavailable(w)
+					return
+				}
+			}
+
+			_, err := w.Write(issuetracker.BuildBatchResponseFor(s.issueTracker, r, s.issues, s.movedIssues, s.assignees))
+			if err != nil {
+				panic(err)
+			}
+			return
+		}
+
 		for issue := range s.issues {
 			if r.URL.Path == issuetracker.IssueURLFrom(s.issueTracker, issue) {
-				_, err := w.Write(issuetracker.BuildResponseFor(s.issueTracker, issue, s.issues[issue]))
+				if s.issues[issue] == issuetracker.StatusUnavailable {
+					respondUnavailable(w)
+					return
+				}
+
+				_, err := w.Write(issuetracker.BuildResponseFor(s.issueTracker, issue, s.issues[issue], s.movedIssues[issue], s.assignees[issue]))
 				if err != nil {
 					panic(err)
 				}
@@ -370,6 +492,13 @@ func (s *TodocheckScenario) setupMockIssueTrackerServer() (teardownFunc, error)
 	}, nil
 }
 
+func respondUnavailable(w http.ResponseWriter) {
+	w.WriteHeader(http.StatusServiceUnavailable)
+	if _, err := w.Write([]byte("Service Unavailable")); err != nil {
+		panic(err)
+	}
+}
+
 func setupMockIssueTrackerCfg(cfgPath string, mockOrigin string) (teardownFunc, error) {
 	patt := regexp.MustCompile("origin: \"?[a-zA-Z0-9._:/]+\"?")
 	origBs, err := os.ReadFile(cfgPath)
@@ -2,6 +2,8 @@ package issuetracker
 
 import (
 	"encoding/json"
+	"net/http"
+	"regexp"
 
 	"github.com/preslavmihaylov/todocheck/testing/scenariobuilder/issuetracker/jira"
 )
@@ -21,12 +23,27 @@ type Status string
 const (
 	StatusClosed Status = "Done"
 	StatusOpen   Status = "Open"
+
+	// StatusNotPlanned is a closed issue, which was resolved as won't do
+	StatusNotPlanned Status = "Not Planned"
+
+	// StatusWontDo is a custom status, which is interpreted as open, unless it's present in the status mapping
+	StatusWontDo Status = "Won't Do"
+
+	// StatusUnavailable makes the mock issue tracker fail all requests for the given issue
+	StatusUnavailable Status = "Unavailable"
 )
 
 var trackerToIssuePath = map[Type]string{
 	Jira: "/rest/api/2/issue/",
 }
 
+var trackerToSearchPath = map[Type]string{
+	Jira: "/rest/api/2/search",
+}
+
+var jqlKeyPattern = regexp.MustCompile(`"([^"]+)"`)
+
 // IssueURLFrom builds the appropriate expected issue url, given the issue tracker type & issue id
 func IssueURLFrom(t Type, issue string) string {
 	path, ok := trackerToIssuePath[t]
@@ -37,25 +54,98 @@ func IssueURLFrom(t Type, issue string) string {
 	return path + issue
 }
 
-// BuildResponseFor given issue tracker type, issue ID and issue status
-func BuildResponseFor(t Type, issue string, status Status) []byte {
+// BuildResponseFor given issue tracker type, issue ID and issue status.
+// If newIssue is not empty, the response is for an issue, which was moved & is now known by newIssue.
+// If assignee is not empty, the issue is assigned to the given user
+func BuildResponseFor(t Type, issue string, status Status, newIssue, assignee string) []byte {
 	switch t {
 	case Jira:
-		res, err := json.Marshal(&jira.Task{
-			Fields: jira.Fields{
-				Status: jira.Status{
-					StatusCategory: jira.StatusCategory{
-						Name: string(status),
-					},
-				},
-			},
-		})
+		task := jiraTaskWith(status)
+		task.Key = issue
+		if newIssue != "" {
+			task.Key = newIssue
+		}
+		if assignee != "" {
+			task.Fields.Assignee = &jira.User{Name: assignee}
+		}
+
+		res, err := json.Marshal(&task)
 		return must(res, err)
 	default:
 		panic("unknown issue tracker received: " + string(t))
 	}
 }
 
+// IsBatchRequest checks if the given request is a batch lookup of multiple issues for the given issue tracker type
+func IsBatchRequest(t Type, r *http.Request) bool {
+	path, ok := trackerToSearchPath[t]
+	return ok && r.URL.Path == path
+}
+
+// BatchRequestedIssues returns the IDs of all issues looked up by the given batch request
+func BatchRequestedIssues(t Type, r *http.Request) []string {
+	switch t {
+	case Jira:
+		var issues []string
+		for _, match := range jqlKeyPattern.FindAllStringSubmatch(r.URL.Query().Get("jql"), -1) {
+			issues = append(issues, match[1])
+		}
+
+		return issues
+	default:
+		panic("unknown issue tracker received: " + string(t))
+	}
+}
+
+// BuildBatchResponseFor given issue tracker type, batch lookup request & all issues available in the issue tracker.
+// Moved issues are returned with their new issue IDs & assigned issues are returned with their assignees
+func BuildBatchResponseFor(t Type, r *http.Request, issues map[string]Status, movedIssues, assignees map[string]string) []byte {
+	switch t {
+	case Jira:
+		res := struct {
+			Issues []jira.Task `json:"issues"`
+		}{Issues: []jira.Task{}}
+		for _, issue := range BatchRequestedIssues(t, r) {
+			if status, ok := issues[issue]; ok {
+				task := jiraTaskWith(status)
+				task.Key = issue
+				if newIssue, ok := movedIssues[issue]; ok {
+					task.Key = newIssue
+				}
+				if assignee, ok := assignees[issue]; ok {
+					task.Fields.Assignee = &jira.User{Name: assignee}
+				}
+
+				res.Issues = append(res.Issues, task)
+			}
+		}
+
+		bs, err := json.Marshal(res)
+		return must(bs, err)
+	default:
+		panic("unknown issue tracker received: " + string(t))
+	}
+}
+
+func jiraTaskWith(status Status) jira.Task {
+	if status == StatusNotPlanned {
+		task := jiraTaskWith(StatusClosed)
+		task.Fields.Resolution = &jira.Resolution{Name: "Won't Do"}
+		return task
+	}
+
+	return jira.Task{
+		Fields: jira.Fields{
+			Status: jira.Status{
+				Name: string(status),
+				StatusCategory: jira.StatusCategory{
+					Name: string(status),
+				},
+			},
+		},
+	}
+}
+
 func must(res []byte, err error) []byte {
 	if err != nil {
 		panic("couldn't marshal response: " + err.Error())
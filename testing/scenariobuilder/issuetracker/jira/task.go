@@ -7,15 +7,27 @@ type StatusCategory struct {
 }
 
 type Status struct {
+	Name           string         `json:"name"`
 	StatusCategory StatusCategory `json:"statusCategory"`
 }
 
+type Resolution struct {
+	Name string `json:"name"`
+}
+
+type User struct {
+	Name string `json:"name"`
+}
+
 type Fields struct {
-	Status Status `json:"status"`
+	Status     Status      `json:"status"`
+	Resolution *Resolution `json:"resolution,omitempty"`
+	Assignee   *User       `json:"assignee,omitempty"`
 }
 
 // Task JSON model as returned by the Jira Rest API
 type Task struct {
+	Key    string `json:"key,omitempty"`
 	Fields Fields `json:"fields"`
 }
 
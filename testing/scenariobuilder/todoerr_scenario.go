@@ -12,6 +12,7 @@ type TodoErrScenario struct {
 	sourceFile    string
 	sourceLineNum int
 	contents      []string
+	message       string
 	metadata      map[string]string
 }
 
@@ -51,6 +52,12 @@ func (s *TodoErrScenario) ExpectLine(line string) *TodoErrScenario {
 	return s
 }
 
+// WithMessage specifies the expected message, which details the todo err
+func (s *TodoErrScenario) WithMessage(msg string) *TodoErrScenario {
+	s.message = msg
+	return s
+}
+
 // WithJSONMetadata extends existing metadata with a multiple of key value pairs
 // expected within the `metadata` field of the TODOs
 func (s *TodoErrScenario) WithJSONMetadata(metadata map[string]string) *TodoErrScenario {
@@ -67,13 +74,20 @@ func (s *TodoErrScenario) WithJSONMetadataEntry(key string, value string) *TodoE
 }
 
 func (s *TodoErrScenario) String() string {
-	str := fmt.Sprintf("ERROR: %s", s.errType)
+	severity := "ERROR"
+	if s.errType.IsWarning() {
+		severity = "WARNING"
+	}
+
+	str := fmt.Sprintf("%s: %s", severity, s.errType)
 	for i := 0; i < len(s.contents); i++ {
 		str += fmt.Sprintf("\n%s:%d: %s", s.sourceFile, i+s.sourceLineNum, s.contents[i])
 	}
 
 	if s.errType == errors.TODOErrTypeMalformed {
 		str += "\n\t> TODO should match pattern - TODO {task_id}:"
+	} else if s.message != "" {
+		str += "\n\t> " + s.message
 	}
 
 	return str
@@ -119,6 +133,8 @@ func (s *TodoErrScenario) ToTodoErrForJSON() *TodoErrForJSON {
 
 	if s.errType == errors.TODOErrTypeMalformed {
 		res.Message = "TODO should match pattern - TODO {task_id}:"
+	} else if s.message != "" {
+		res.Message = s.message
 	}
 
 	return res
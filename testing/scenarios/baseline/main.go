@@ -0,0 +1,13 @@
+package main
+
+// TODO This is a new malformed todo
+
+// TODO This is a malformed todo, which is in the baseline
+
+// TODO J456:   This is a todo, annotated with a closed issue, which is in the baseline
+
+// TODO J456: This is a new todo, annotated with a closed issue
+
+var retries = 3 // TODO J456: Make the retries configurable, which is in the baseline despite the code next to it
+
+func main() {}
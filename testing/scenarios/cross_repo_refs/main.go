@@ -0,0 +1,11 @@
+package main
+
+// TODO preslavmihaylov/other-repo#12: This is a todo, annotated with an issue of another repository
+
+// TODO preslavmihaylov/todocheck#12, #13: This is a todo, annotated with an issue of the origin repository
+
+// TODO otheruser/repo#7: This is a todo, annotated with an issue of a repository, which is not allowed
+
+// TODO other/group/repo#7: This is a todo, annotated with an invalid github issue reference
+
+func main() {}
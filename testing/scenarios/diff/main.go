@@ -0,0 +1,13 @@
+package main
+
+// TODO This is an unchanged malformed todo
+
+// TODO This is a changed malformed todo
+
+// TODO J456: This is a changed todo, annotated with a closed issue
+
+/*
+ * TODO This is a malformed multi-line todo
+ * with a changed line
+ */
+func main() {}
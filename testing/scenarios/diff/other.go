@@ -0,0 +1,3 @@
+package main
+
+// TODO This is a malformed todo in an unchanged file
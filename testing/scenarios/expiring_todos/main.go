@@ -0,0 +1,16 @@
+package main
+
+// TODO J-123 (until 2026-12-31): This is a todo, which expired even though its issue is open
+
+// TODO J-321 (until 2027-01-01): This is a valid todo, which expires today
+
+/*
+ * TODO J-321 (until 2026-01-01): This is a multiline todo,
+ * which expired as well
+ */
+
+// TODO J-321 (until 2026-13-01): This is a malformed todo with an invalid expiry date
+
+// TODO J-321: This is a valid todo without an expiry date
+
+func main() {}
@@ -0,0 +1,7 @@
+package main
+
+// TODO J123: This is a todo, annotated with an issue, whose status can't be fetched
+
+// TODO J321: This is a valid todo, annotated with an open issue
+
+func main() {}
@@ -0,0 +1,9 @@
+package main
+
+// TODO https://github.com/preslavmihaylov/todocheck/issues/12: This is a todo, annotated with an issue URL
+
+// TODO https://github.com/preslavmihaylov/other-repo/issues/12: This is a todo, annotated with an issue URL of another repository
+
+// TODO https://github.com/preslavmihaylov/todocheck/wiki: This is a todo, annotated with a URL, which isn't an issue
+
+func main() {}
@@ -0,0 +1,3 @@
+#!/bin/bash
+
+# TODO https://github.com/preslavmihaylov/todocheck/pull/3: This is a todo, annotated with a pull request URL
@@ -0,0 +1,10 @@
+package main
+
+// TODO J123: This is a todo, annotated with an open issue
+
+// TODO J456: This is a todo, annotated with a closed issue
+
+/*
+ * TODO J123, J456: This is a multi-line todo, annotated with both issues
+ */
+func main() {} // TODO This is a malformed todo
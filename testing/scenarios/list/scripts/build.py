@@ -0,0 +1 @@
+# TODO J123: This is a todo in a python script
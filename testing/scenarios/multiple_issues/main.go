@@ -0,0 +1,9 @@
+package main
+
+// TODO J123, J321: This is a valid todo, annotated with two open issues
+
+// TODO J123, J456: This is a todo, annotated with an open & a closed issue
+
+// TODO J456, J654: This is a todo, annotated with a closed & a non-existent issue
+
+func main() {}
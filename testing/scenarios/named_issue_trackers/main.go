@@ -0,0 +1,13 @@
+package main
+
+// TODO J123: This is a valid todo, annotated with an issue of the default issue tracker
+
+// TODO upstream:J123: This is a valid todo, annotated with an issue of a named issue tracker
+
+// TODO upstream:J456: This is a todo, annotated with a closed issue of a named issue tracker
+
+// TODO UP-1: This is a todo, annotated with an issue, which matches the id pattern of a named issue tracker
+
+// TODO unknown:J123: This is a todo, whose prefix is not the name of an issue tracker, so it references the unknown issue
+
+func main() {}
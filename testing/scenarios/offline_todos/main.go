@@ -0,0 +1,14 @@
+package main
+
+// TODO 1: This is a todo with a valid github issue reference
+
+// TODO #2: This is a todo with a valid github issue reference, prefixed with a hashtag
+
+// TODO J123: This is a todo with an issue reference, which is not valid for github
+
+// TODO: This is a malformed todo
+
+/*
+ * TODO GH-3:
+ * This is another issue reference, which is not valid for github
+ */
@@ -0,0 +1,7 @@
+package legacy
+
+// todo J123: This is a valid todo, matched via the overridden case sensitivity
+
+// FIXME J456: This is a todo, matched via the overridden custom todos & annotated with a closed issue
+
+// FIXME This is a malformed todo
@@ -0,0 +1,7 @@
+package main
+
+// TODO J123: This is a valid todo, annotated with an issue of the default issue tracker
+
+// FIXME This is not a todo outside of the overridden directories
+
+func main() {}
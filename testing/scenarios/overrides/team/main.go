@@ -0,0 +1,7 @@
+package team
+
+// TODO J123: This is a valid todo, annotated with an issue of the overridden issue tracker
+
+// TODO J456: This is a todo, annotated with a closed issue of the overridden issue tracker
+
+// TODO UP-1: This is a todo, annotated with a moved issue of the overridden issue tracker
@@ -0,0 +1,21 @@
+package main
+
+// todocheck:ignore-next-line: waiting for the upstream fix
+// TODO J456: This is a todo, whose closed issue is suppressed by the previous line
+
+// TODO J456: This is a todo, whose closed issue is suppressed on the same line todocheck:ignore
+
+// todocheck:ignore-next-line
+// TODO J123: This is a valid todo, so the previous line doesn't suppress anything
+
+// todocheck:disable: legacy code
+// TODO J456: This is a todo, whose closed issue is suppressed by a disabled block
+// TODO This is a malformed todo, suppressed by a disabled block
+// todocheck:enable
+
+// TODO J456: This is a todo, annotated with a closed issue after the disabled block
+
+// todocheck:ignore-next-line (until 2026-01-31): expired suppression
+// TODO J456: This is a todo, annotated with a closed issue, whose suppression expired
+
+func main() {}
@@ -0,0 +1,5 @@
+# todocheck:ignore-next-line
+# TODO J456: This is a todo, whose closed issue is suppressed in a python comment
+
+print("todocheck:ignore-next-line isn't a directive in a string")
+# TODO J456: This is a todo, annotated with a closed issue
@@ -0,0 +1,11 @@
+package main
+
+// TODO(alice) J123: This is a todo, whose owner is assigned to its issue
+
+// TODO(bob) J321: This is a todo, whose owner is not assigned to its issue
+
+// TODO(bob) J456: This is a todo, whose issue is not assigned to anyone
+
+// TODO J654: This is a todo without an owner
+
+func main() {}
@@ -0,0 +1,3 @@
+# TODO(@Alice) J123: This is a todo, whose owner is assigned to its issue
+
+# TODO(@bob) J789: This is a todo, whose owner is not assigned to its issue
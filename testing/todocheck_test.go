@@ -169,6 +169,630 @@ func TestAnnotatedTodos(t *testing.T) {
 	}
 }
 
+func TestIssueStatusUnknownWarnings(t *testing.T) {
+	err := scenariobuilder.NewScenario().
+		WithBinary("../todocheck").
+		WithBasepath("./scenarios/issue_status_unknown").
+		WithConfig("./test_configs/on_tracker_error_warn.yaml").
+		WithIssueTracker(issuetracker.Jira).
+		WithIssue("J123", issuetracker.StatusUnavailable).
+		WithIssue("J321", issuetracker.StatusOpen).
+		ExpectTodoErr(
+			scenariobuilder.NewTodoErr().
+				WithType(errors.TODOErrTypeIssueStatusUnknown).
+				WithLocation("scenarios/issue_status_unknown/main.go", 3).
+				ExpectLine("// TODO J123: This is a todo, annotated with an issue, whose status can't be fetched").
+				WithMessage("couldn't fetch issue status: bad status code upon fetching task: 503 - Service Unavailable")).
+		Run()
+	if err != nil {
+		t.Errorf("%s", err)
+	}
+}
+
+func TestIssueStatusUnknownWarningsWithJSONOutput(t *testing.T) {
+	err := scenariobuilder.NewScenario().
+		WithBinary("../todocheck").
+		WithBasepath("./scenarios/issue_status_unknown").
+		WithConfig("./test_configs/on_tracker_error_warn.yaml").
+		WithJSONOutput().
+		WithIssueTracker(issuetracker.Jira).
+		WithIssue("J123", issuetracker.StatusUnavailable).
+		WithIssue("J321", issuetracker.StatusOpen).
+		ExpectTodoErr(
+			scenariobuilder.NewTodoErr().
+				WithType(errors.TODOErrTypeIssueStatusUnknown).
+				WithLocation("scenarios/issue_status_unknown/main.go", 3).
+				WithMessage("couldn't fetch issue status: bad status code upon fetching task: 503 - Service Unavailable").
+				WithJSONMetadataEntry("issueID", "J123")).
+		Run()
+	if err != nil {
+		t.Errorf("%s", err)
+	}
+}
+
+func TestSkippedIssueStatusUnknown(t *testing.T) {
+	err := scenariobuilder.NewScenario().
+		WithBinary("../todocheck").
+		WithBasepath("./scenarios/annotated_todos").
+		WithConfig("./test_configs/on_tracker_error_skip.yaml").
+		WithIssueTracker(issuetracker.Jira).
+		WithIssue("J123", issuetracker.StatusUnavailable).
+		WithIssue("J321", issuetracker.StatusOpen).
+		ExpectTodoErr(
+			scenariobuilder.NewTodoErr().
+				WithType(errors.TODOErrTypeNonExistentIssue).
+				WithLocation("scenarios/annotated_todos/main.go", 7).
+				ExpectLine("// TODO J456: This is an invalid todo, annotated with a non-existent issue")).
+		ExpectTodoErr(
+			scenariobuilder.NewTodoErr().
+				WithType(errors.TODOErrTypeNonExistentIssue).
+				WithLocation("scenarios/annotated_todos/main.go", 19).
+				ExpectLine("/*").
+				ExpectLine(" * TODO J456:").
+				ExpectLine(" * This issue doesn't exist").
+				ExpectLine(" */")).
+		ExpectTodoErr(
+			scenariobuilder.NewTodoErr().
+				WithType(errors.TODOErrTypeMalformed).
+				WithLocation("scenarios/annotated_todos/main.go", 24).
+				ExpectLine("/* This is a malformed TODO:").
+				ExpectLine(" */")).
+		Run()
+	if err != nil {
+		t.Errorf("%s", err)
+	}
+}
+
+func TestIssueStatusUnknownFails(t *testing.T) {
+	err := scenariobuilder.NewScenario().
+		WithBinary("../todocheck").
+		WithBasepath("./scenarios/issue_status_unknown").
+		WithConfig("./test_configs/on_tracker_error_fail.yaml").
+		WithIssueTracker(issuetracker.Jira).
+		WithIssue("J123", issuetracker.StatusUnavailable).
+		WithIssue("J321", issuetracker.StatusOpen).
+		ExpectExecutionError().
+		Run()
+	if err != nil {
+		t.Errorf("%s", err)
+	}
+}
+
+func TestStatusMapping(t *testing.T) {
+	err := scenariobuilder.NewScenario().
+		WithBinary("../todocheck").
+		WithBasepath("./scenarios/status_mapping").
+		WithConfig("./test_configs/status_mapping.yaml").
+		WithIssueTracker(issuetracker.Jira).
+		WithIssue("J123", issuetracker.StatusWontDo).
+		WithIssue("J321", issuetracker.StatusOpen).
+		ExpectTodoErr(
+			scenariobuilder.NewTodoErr().
+				WithType(errors.TODOErrTypeIssueClosed).
+				WithLocation("scenarios/status_mapping/main.go", 3).
+				ExpectLine("// TODO J123: This is a todo, annotated with an issue, whose custom status is mapped to closed")).
+		Run()
+	if err != nil {
+		t.Errorf("%s", err)
+	}
+}
+
+func TestIssueClosedAsNotPlanned(t *testing.T) {
+	err := scenariobuilder.NewScenario().
+		WithBinary("../todocheck").
+		WithBasepath("./scenarios/closed_issue_reasons").
+		WithConfig("./test_configs/no_issue_tracker.yaml").
+		WithIssueTracker(issuetracker.Jira).
+		WithIssue("J123", issuetracker.StatusNotPlanned).
+		WithIssue("J321", issuetracker.StatusOpen).
+		ExpectTodoErr(
+			scenariobuilder.NewTodoErr().
+				WithType(errors.TODOErrTypeIssueNotPlanned).
+				WithLocation("scenarios/closed_issue_reasons/main.go", 3).
+				ExpectLine("// TODO J123: This is a todo, annotated with an issue, which was closed as not planned").
+				WithMessage("issue J123 was closed as not planned, remove your TODO or re-link it")).
+		Run()
+	if err != nil {
+		t.Errorf("%s", err)
+	}
+}
+
+func TestTodoOwners(t *testing.T) {
+	err := scenariobuilder.NewScenario().
+		WithBinary("../todocheck").
+		WithBasepath("./scenarios/todo_owners").
+		WithConfig("./test_configs/owner_policy.yaml").
+		WithIssueTracker(issuetracker.Jira).
+		WithAssignedIssue("J123", issuetracker.StatusOpen, "alice").
+		WithAssignedIssue("J321", issuetracker.StatusOpen, "alice").
+		WithIssue("J456", issuetracker.StatusOpen).
+		WithAssignedIssue("J654", issuetracker.StatusOpen, "alice").
+		WithAssignedIssue("J789", issuetracker.StatusOpen, "carol").
+		ExpectTodoErr(
+			scenariobuilder.NewTodoErr().
+				WithType(errors.TODOErrTypeOwnerMismatch).
+				WithLocation("scenarios/todo_owners/main.go", 5).
+				ExpectLine("// TODO(bob) J321: This is a todo, whose owner is not assigned to its issue").
+				WithMessage("todo owner bob is not assigned to issue J321, which is assigned to alice")).
+		ExpectTodoErr(
+			scenariobuilder.NewTodoErr().
+				WithType(errors.TODOErrTypeOwnerMismatch).
+				WithLocation("scenarios/todo_owners/script.py", 3).
+				ExpectLine("# TODO(@bob) J789: This is a todo, whose owner is not assigned to its issue").
+				WithMessage("todo owner @bob is not assigned to issue J789, which is assigned to carol")).
+		Run()
+	if err != nil {
+		t.Errorf("%s", err)
+	}
+}
+
+func TestTodoOwnersAreIgnoredByDefault(t *testing.T) {
+	err := scenariobuilder.NewScenario().
+		WithBinary("../todocheck").
+		WithBasepath("./scenarios/todo_owners").
+		WithConfig("./test_configs/no_issue_tracker.yaml").
+		WithIssueTracker(issuetracker.Jira).
+		WithAssignedIssue("J123", issuetracker.StatusOpen, "alice").
+		WithAssignedIssue("J321", issuetracker.StatusOpen, "alice").
+		WithIssue("J456", issuetracker.StatusOpen).
+		WithAssignedIssue("J654", issuetracker.StatusOpen, "alice").
+		WithAssignedIssue("J789", issuetracker.StatusOpen, "carol").
+		Run()
+	if err != nil {
+		t.Errorf("%s", err)
+	}
+}
+
+func TestMultipleIssues(t *testing.T) {
+	err := scenariobuilder.NewScenario().
+		WithBinary("../todocheck").
+		WithBasepath("./scenarios/multiple_issues").
+		WithConfig("./test_configs/no_issue_tracker.yaml").
+		WithIssueTracker(issuetracker.Jira).
+		WithIssue("J123", issuetracker.StatusOpen).
+		WithIssue("J321", issuetracker.StatusOpen).
+		WithIssue("J456", issuetracker.StatusClosed).
+		ExpectTodoErr(
+			scenariobuilder.NewTodoErr().
+				WithType(errors.TODOErrTypeIssueClosed).
+				WithLocation("scenarios/multiple_issues/main.go", 5).
+				ExpectLine("// TODO J123, J456: This is a todo, annotated with an open & a closed issue")).
+		ExpectTodoErr(
+			scenariobuilder.NewTodoErr().
+				WithType(errors.TODOErrTypeIssueClosed).
+				WithLocation("scenarios/multiple_issues/main.go", 7).
+				ExpectLine("// TODO J456, J654: This is a todo, annotated with a closed & a non-existent issue")).
+		ExpectTodoErr(
+			scenariobuilder.NewTodoErr().
+				WithType(errors.TODOErrTypeNonExistentIssue).
+				WithLocation("scenarios/multiple_issues/main.go", 7).
+				ExpectLine("// TODO J456, J654: This is a todo, annotated with a closed & a non-existent issue")).
+		Run()
+	if err != nil {
+		t.Errorf("%s", err)
+	}
+}
+
+func TestMultipleIssuesWithAnyOpenIssueRequired(t *testing.T) {
+	err := scenariobuilder.NewScenario().
+		WithBinary("../todocheck").
+		WithBasepath("./scenarios/multiple_issues").
+		WithConfig("./test_configs/required_open_issues_any.yaml").
+		WithIssueTracker(issuetracker.Jira).
+		WithIssue("J123", issuetracker.StatusOpen).
+		WithIssue("J321", issuetracker.StatusOpen).
+		WithIssue("J456", issuetracker.StatusClosed).
+		ExpectTodoErr(
+			scenariobuilder.NewTodoErr().
+				WithType(errors.TODOErrTypeIssueClosed).
+				WithLocation("scenarios/multiple_issues/main.go", 7).
+				ExpectLine("// TODO J456, J654: This is a todo, annotated with a closed & a non-existent issue")).
+		ExpectTodoErr(
+			scenariobuilder.NewTodoErr().
+				WithType(errors.TODOErrTypeNonExistentIssue).
+				WithLocation("scenarios/multiple_issues/main.go", 7).
+				ExpectLine("// TODO J456, J654: This is a todo, annotated with a closed & a non-existent issue")).
+		Run()
+	if err != nil {
+		t.Errorf("%s", err)
+	}
+}
+
+func TestNamedIssueTrackers(t *testing.T) {
+	err := scenariobuilder.NewScenario().
+		WithBinary("../todocheck").
+		WithBasepath("./scenarios/named_issue_trackers").
+		WithConfig("./test_configs/named_issue_trackers.yaml").
+		WithIssueTracker(issuetracker.Jira).
+		WithIssue("J123", issuetracker.StatusOpen).
+		WithIssue("J456", issuetracker.StatusClosed).
+		WithMovedIssue("UP-1", "UP-2").
+		ExpectTodoErr(
+			scenariobuilder.NewTodoErr().
+				WithType(errors.TODOErrTypeIssueClosed).
+				WithLocation("scenarios/named_issue_trackers/main.go", 7).
+				ExpectLine("// TODO upstream:J456: This is a todo, annotated with a closed issue of a named issue tracker")).
+		ExpectTodoErr(
+			scenariobuilder.NewTodoErr().
+				WithType(errors.TODOErrTypeIssueMoved).
+				WithLocation("scenarios/named_issue_trackers/main.go", 9).
+				ExpectLine("// TODO UP-1: This is a todo, annotated with an issue, which matches the id pattern of a named issue tracker").
+				WithMessage("issue upstream:UP-1 was moved to upstream:UP-2, re-link your TODO")).
+		ExpectTodoErr(
+			scenariobuilder.NewTodoErr().
+				WithType(errors.TODOErrTypeNonExistentIssue).
+				WithLocation("scenarios/named_issue_trackers/main.go", 11).
+				ExpectLine("// TODO unknown:J123: This is a todo, whose prefix is not the name of an issue tracker, so it references the unknown issue")).
+		Run()
+	if err != nil {
+		t.Errorf("%s", err)
+	}
+}
+
+func TestOverrides(t *testing.T) {
+	err := scenariobuilder.NewScenario().
+		WithBinary("../todocheck").
+		WithBasepath("./scenarios/overrides").
+		WithConfig("./test_configs/overrides.yaml").
+		WithIssueTracker(issuetracker.Jira).
+		WithIssue("J123", issuetracker.StatusOpen).
+		WithIssue("J456", issuetracker.StatusClosed).
+		WithMovedIssue("UP-1", "UP-2").
+		ExpectTodoErr(
+			scenariobuilder.NewTodoErr().
+				WithType(errors.TODOErrTypeIssueClosed).
+				WithLocation("scenarios/overrides/legacy/main.go", 5).
+				ExpectLine("// FIXME J456: This is a todo, matched via the overridden custom todos & annotated with a closed issue")).
+		ExpectTodoErr(
+			scenariobuilder.NewTodoErr().
+				WithType(errors.TODOErrTypeMalformed).
+				WithLocation("scenarios/overrides/legacy/main.go", 7).
+				ExpectLine("// FIXME This is a malformed todo")).
+		ExpectTodoErr(
+			scenariobuilder.NewTodoErr().
+				WithType(errors.TODOErrTypeIssueClosed).
+				WithLocation("scenarios/overrides/team/main.go", 5).
+				ExpectLine("// TODO J456: This is a todo, annotated with a closed issue of the overridden issue tracker")).
+		ExpectTodoErr(
+			scenariobuilder.NewTodoErr().
+				WithType(errors.TODOErrTypeIssueMoved).
+				WithLocation("scenarios/overrides/team/main.go", 7).
+				ExpectLine("// TODO UP-1: This is a todo, annotated with a moved issue of the overridden issue tracker").
+				WithMessage("issue UP-1 was moved to UP-2, re-link your TODO")).
+		Run()
+	if err != nil {
+		t.Errorf("%s", err)
+	}
+}
+
+func TestSuppressions(t *testing.T) {
+	err := scenariobuilder.NewScenario().
+		WithBinary("../todocheck").
+		WithBasepath("./scenarios/suppressions").
+		WithConfig("./test_configs/no_issue_tracker.yaml").
+		WithIssueTracker(issuetracker.Jira).
+		WithNow("2026-06-15").
+		WithIssue("J123", issuetracker.StatusOpen).
+		WithIssue("J456", issuetracker.StatusClosed).
+		ExpectTodoErr(
+			scenariobuilder.NewTodoErr().
+				WithType(errors.TODOErrTypeIssueClosed).
+				WithLocation("scenarios/suppressions/main.go", 16).
+				ExpectLine("// TODO J456: This is a todo, annotated with a closed issue after the disabled block")).
+		ExpectTodoErr(
+			scenariobuilder.NewTodoErr().
+				WithType(errors.TODOErrTypeIssueClosed).
+				WithLocation("scenarios/suppressions/main.go", 19).
+				ExpectLine("// TODO J456: This is a todo, annotated with a closed issue, whose suppression expired")).
+		ExpectTodoErr(
+			scenariobuilder.NewTodoErr().
+				WithType(errors.TODOErrTypeIssueClosed).
+				WithLocation("scenarios/suppressions/script.py", 5).
+				ExpectLine("# TODO J456: This is a todo, annotated with a closed issue")).
+		Run()
+	if err != nil {
+		t.Errorf("%s", err)
+	}
+}
+
+func TestUnusedSuppressions(t *testing.T) {
+	err := scenariobuilder.NewScenario().
+		WithBinary("../todocheck").
+		WithBasepath("./scenarios/suppressions").
+		WithConfig("./test_configs/no_issue_tracker.yaml").
+		WithIssueTracker(issuetracker.Jira).
+		WithNow("2026-06-15").
+		WithReportUnusedSuppressionsFlag().
+		WithIssue("J123", issuetracker.StatusOpen).
+		WithIssue("J456", issuetracker.StatusClosed).
+		ExpectTodoErr(
+			scenariobuilder.NewTodoErr().
+				WithType(errors.TODOErrTypeIssueClosed).
+				WithLocation("scenarios/suppressions/main.go", 16).
+				ExpectLine("// TODO J456: This is a todo, annotated with a closed issue after the disabled block")).
+		ExpectTodoErr(
+			scenariobuilder.NewTodoErr().
+				WithType(errors.TODOErrTypeIssueClosed).
+				WithLocation("scenarios/suppressions/main.go", 19).
+				ExpectLine("// TODO J456: This is a todo, annotated with a closed issue, whose suppression expired")).
+		ExpectTodoErr(
+			scenariobuilder.NewTodoErr().
+				WithType(errors.TODOErrTypeIssueClosed).
+				WithLocation("scenarios/suppressions/script.py", 5).
+				ExpectLine("# TODO J456: This is a todo, annotated with a closed issue")).
+		ExpectTodoErr(
+			scenariobuilder.NewTodoErr().
+				WithType(errors.TODOErrTypeUnusedSuppression).
+				WithLocation("scenarios/suppressions/main.go", 8).
+				ExpectLine("// todocheck:ignore-next-line").
+				WithMessage("todocheck:ignore-next-line doesn't suppress any todo errors, remove it")).
+		ExpectTodoErr(
+			scenariobuilder.NewTodoErr().
+				WithType(errors.TODOErrTypeUnusedSuppression).
+				WithLocation("scenarios/suppressions/main.go", 18).
+				ExpectLine("// todocheck:ignore-next-line (until 2026-01-31): expired suppression").
+				WithMessage("todocheck:ignore-next-line expired after 2026-01-31 & no longer suppresses todo errors, remove it")).
+		Run()
+	if err != nil {
+		t.Errorf("%s", err)
+	}
+}
+
+func TestBaseline(t *testing.T) {
+	err := scenariobuilder.NewScenario().
+		WithBinary("../todocheck").
+		WithBasepath("./scenarios/baseline").
+		WithConfig("./test_configs/baseline.yaml").
+		WithIssueTracker(issuetracker.Jira).
+		WithIssue("J456", issuetracker.StatusClosed).
+		ExpectTodoErr(
+			scenariobuilder.NewTodoErr().
+				WithType(errors.TODOErrTypeMalformed).
+				WithLocation("scenarios/baseline/main.go", 3).
+				ExpectLine("// TODO This is a new malformed todo")).
+		ExpectTodoErr(
+			scenariobuilder.NewTodoErr().
+				WithType(errors.TODOErrTypeIssueClosed).
+				WithLocation("scenarios/baseline/main.go", 9).
+				ExpectLine("// TODO J456: This is a new todo, annotated with a closed issue")).
+		Run()
+	if err != nil {
+		t.Errorf("%s", err)
+	}
+}
+
+func TestDiffFile(t *testing.T) {
+	err := scenariobuilder.NewScenario().
+		WithBinary("../todocheck").
+		WithBasepath("./scenarios/diff").
+		WithConfig("./test_configs/no_issue_tracker.yaml").
+		WithIssueTracker(issuetracker.Jira).
+		WithIssue("J456", issuetracker.StatusClosed).
+		WithDiffFile("./scenarios/diff/changes.diff").
+		ExpectTodoErr(
+			scenariobuilder.NewTodoErr().
+				WithType(errors.TODOErrTypeMalformed).
+				WithLocation("scenarios/diff/main.go", 5).
+				ExpectLine("// TODO This is a changed malformed todo")).
+		ExpectTodoErr(
+			scenariobuilder.NewTodoErr().
+				WithType(errors.TODOErrTypeIssueClosed).
+				WithLocation("scenarios/diff/main.go", 7).
+				ExpectLine("// TODO J456: This is a changed todo, annotated with a closed issue")).
+		ExpectTodoErr(
+			scenariobuilder.NewTodoErr().
+				WithType(errors.TODOErrTypeMalformed).
+				WithLocation("scenarios/diff/main.go", 9).
+				ExpectLine("/*").
+				ExpectLine(" * TODO This is a malformed multi-line todo").
+				ExpectLine(" * with a changed line").
+				ExpectLine(" */")).
+		Run()
+	if err != nil {
+		t.Errorf("%s", err)
+	}
+}
+
+func TestFileArguments(t *testing.T) {
+	err := scenariobuilder.NewScenario().
+		WithBinary("../todocheck").
+		WithBasepath("./scenarios/diff").
+		WithConfig("./test_configs/no_issue_tracker.yaml").
+		WithIssueTracker(issuetracker.Jira).
+		WithPaths("./scenarios/diff/other.go", "scenarios/diff/other.go").
+		ExpectTodoErr(
+			scenariobuilder.NewTodoErr().
+				WithType(errors.TODOErrTypeMalformed).
+				WithLocation("scenarios/diff/other.go", 3).
+				ExpectLine("// TODO This is a malformed todo in an unchanged file")).
+		Run()
+	if err != nil {
+		t.Errorf("%s", err)
+	}
+}
+
+func TestStdin(t *testing.T) {
+	err := scenariobuilder.NewScenario().
+		WithBinary("../todocheck").
+		WithBasepath("./scenarios/diff").
+		WithConfig("./test_configs/no_issue_tracker.yaml").
+		WithIssueTracker(issuetracker.Jira).
+		WithIssue("J456", issuetracker.StatusClosed).
+		WithStdin("scenarios/diff/unsaved.py", "# TODO J456: This is a todo, annotated with a closed issue\nprint('unsaved')\n# TODO This is a malformed todo\n").
+		ExpectTodoErr(
+			scenariobuilder.NewTodoErr().
+				WithType(errors.TODOErrTypeIssueClosed).
+				WithLocation("scenarios/diff/unsaved.py", 1).
+				ExpectLine("# TODO J456: This is a todo, annotated with a closed issue")).
+		ExpectTodoErr(
+			scenariobuilder.NewTodoErr().
+				WithType(errors.TODOErrTypeMalformed).
+				WithLocation("scenarios/diff/unsaved.py", 3).
+				ExpectLine("# TODO This is a malformed todo")).
+		Run()
+	if err != nil {
+		t.Errorf("%s", err)
+	}
+}
+
+func TestList(t *testing.T) {
+	err := scenariobuilder.NewScenario().
+		WithBinary("../todocheck").
+		WithCommand("list").
+		WithBasepath("./scenarios/list").
+		WithConfig("./test_configs/no_issue_tracker.yaml").
+		WithIssueTracker(issuetracker.Jira).
+		WithIssue("J123", issuetracker.StatusOpen).
+		WithIssue("J456", issuetracker.StatusClosed).
+		ExpectOutputText(
+			"scenarios/list/main.go:3: TODO J123 (open) - // TODO J123: This is a todo, annotated with an open issue\n" +
+				"scenarios/list/main.go:5: TODO J456 (closed) - // TODO J456: This is a todo, annotated with a closed issue\n" +
+				"scenarios/list/main.go:7: TODO J123 (open), J456 (closed) - /* * TODO J123, J456: This is a multi-line todo, annotated with both issues */\n" +
+				"scenarios/list/main.go:10: TODO (malformed) - // TODO This is a malformed todo\n" +
+				"scenarios/list/scripts/build.py:1: TODO J123 (open) - # TODO J123: This is a todo in a python script\n").
+		Run()
+	if err != nil {
+		t.Errorf("%s", err)
+	}
+}
+
+func TestListFilters(t *testing.T) {
+	err := scenariobuilder.NewScenario().
+		WithBinary("../todocheck").
+		WithCommand("list", "--issue", "J123", "--path", "scenarios/list/scripts").
+		WithBasepath("./scenarios/list").
+		WithConfig("./test_configs/no_issue_tracker.yaml").
+		WithIssueTracker(issuetracker.Jira).
+		WithIssue("J123", issuetracker.StatusOpen).
+		WithIssue("J456", issuetracker.StatusClosed).
+		ExpectOutputText("scenarios/list/scripts/build.py:1: TODO J123 (open) - # TODO J123: This is a todo in a python script\n").
+		Run()
+	if err != nil {
+		t.Errorf("%s", err)
+	}
+
+	err = scenariobuilder.NewScenario().
+		WithBinary("../todocheck").
+		WithCommand("list", "--status", "closed", "--keyword", "todo").
+		WithBasepath("./scenarios/list").
+		WithConfig("./test_configs/no_issue_tracker.yaml").
+		WithIssueTracker(issuetracker.Jira).
+		WithIssue("J123", issuetracker.StatusOpen).
+		WithIssue("J456", issuetracker.StatusClosed).
+		ExpectOutputText(
+			"scenarios/list/main.go:5: TODO J456 (closed) - // TODO J456: This is a todo, annotated with a closed issue\n" +
+				"scenarios/list/main.go:7: TODO J123 (open), J456 (closed) - /* * TODO J123, J456: This is a multi-line todo, annotated with both issues */\n").
+		Run()
+	if err != nil {
+		t.Errorf("%s", err)
+	}
+}
+
+func TestMovedIssues(t *testing.T) {
+	err := scenariobuilder.NewScenario().
+		WithBinary("../todocheck").
+		WithBasepath("./scenarios/moved_issues").
+		WithConfig("./test_configs/no_issue_tracker.yaml").
+		WithIssueTracker(issuetracker.Jira).
+		WithMovedIssue("J123", "K5").
+		WithIssue("J321", issuetracker.StatusOpen).
+		ExpectTodoErr(
+			scenariobuilder.NewTodoErr().
+				WithType(errors.TODOErrTypeIssueMoved).
+				WithLocation("scenarios/moved_issues/main.go", 3).
+				ExpectLine("// TODO J123: This is a todo, annotated with an issue, which was moved to another project").
+				WithMessage("issue J123 was moved to K5, re-link your TODO")).
+		Run()
+	if err != nil {
+		t.Errorf("%s", err)
+	}
+}
+
+func TestMovedIssuesWithJSONOutput(t *testing.T) {
+	err := scenariobuilder.NewScenario().
+		WithBinary("../todocheck").
+		WithBasepath("./scenarios/moved_issues").
+		WithConfig("./test_configs/no_issue_tracker.yaml").
+		WithJSONOutput().
+		WithIssueTracker(issuetracker.Jira).
+		WithMovedIssue("J123", "K5").
+		WithIssue("J321", issuetracker.StatusOpen).
+		ExpectTodoErr(
+			scenariobuilder.NewTodoErr().
+				WithType(errors.TODOErrTypeIssueMoved).
+				WithLocation("scenarios/moved_issues/main.go", 3).
+				ExpectLine("// TODO J123: This is a todo, annotated with an issue, which was moved to another project").
+				WithMessage("issue J123 was moved to K5, re-link your TODO").
+				WithJSONMetadataEntry("issueID", "J123").
+				WithJSONMetadataEntry("movedTo", "K5")).
+		Run()
+	if err != nil {
+		t.Errorf("%s", err)
+	}
+}
+
+func TestExpiringTodos(t *testing.T) {
+	err := scenariobuilder.NewScenario().
+		WithBinary("../todocheck").
+		WithBasepath("./scenarios/expiring_todos").
+		WithConfig("./test_configs/no_issue_tracker.yaml").
+		WithNow("2027-01-01").
+		WithIssueTracker(issuetracker.Jira).
+		WithIssue("J-123", issuetracker.StatusOpen).
+		WithIssue("J-321", issuetracker.StatusOpen).
+		ExpectTodoErr(
+			scenariobuilder.NewTodoErr().
+				WithType(errors.TODOErrTypeExpired).
+				WithLocation("scenarios/expiring_todos/main.go", 3).
+				ExpectLine("// TODO J-123 (until 2026-12-31): This is a todo, which expired even though its issue is open").
+				WithMessage("todo expired after 2026-12-31, resolve it or extend its deadline")).
+		ExpectTodoErr(
+			scenariobuilder.NewTodoErr().
+				WithType(errors.TODOErrTypeExpired).
+				WithLocation("scenarios/expiring_todos/main.go", 7).
+				ExpectLine("/*").
+				ExpectLine(" * TODO J-321 (until 2026-01-01): This is a multiline todo,").
+				ExpectLine(" * which expired as well").
+				ExpectLine(" */").
+				WithMessage("todo expired after 2026-01-01, resolve it or extend its deadline")).
+		ExpectTodoErr(
+			scenariobuilder.NewTodoErr().
+				WithType(errors.TODOErrTypeMalformed).
+				WithLocation("scenarios/expiring_todos/main.go", 12).
+				ExpectLine("// TODO J-321 (until 2026-13-01): This is a malformed todo with an invalid expiry date")).
+		Run()
+	if err != nil {
+		t.Errorf("%s", err)
+	}
+}
+
+func TestExpiringTodosInOfflineMode(t *testing.T) {
+	err := scenariobuilder.NewScenario().
+		WithBinary("../todocheck").
+		WithBasepath("./scenarios/expiring_todos").
+		WithConfig("./test_configs/no_issue_tracker.yaml").
+		WithOfflineFlag().
+		WithNow("2026-12-31T12:00:00Z").
+		ExpectTodoErr(
+			scenariobuilder.NewTodoErr().
+				WithType(errors.TODOErrTypeExpired).
+				WithLocation("scenarios/expiring_todos/main.go", 7).
+				ExpectLine("/*").
+				ExpectLine(" * TODO J-321 (until 2026-01-01): This is a multiline todo,").
+				ExpectLine(" * which expired as well").
+				ExpectLine(" */").
+				WithMessage("todo expired after 2026-01-01, resolve it or extend its deadline")).
+		ExpectTodoErr(
+			scenariobuilder.NewTodoErr().
+				WithType(errors.TODOErrTypeMalformed).
+				WithLocation("scenarios/expiring_todos/main.go", 12).
+				ExpectLine("// TODO J-321 (until 2026-13-01): This is a malformed todo with an invalid expiry date")).
+		Run()
+	if err != nil {
+		t.Errorf("%s", err)
+	}
+}
+
 func TestAnnotatedTodosWithJSONOutput(t *testing.T) {
 	err := scenariobuilder.NewScenario().
 		WithBinary("../todocheck").
@@ -445,6 +1069,35 @@ func TestIgnoredDirectories(t *testing.T) {
 	}
 }
 
+func TestIgnoredFileArguments(t *testing.T) {
+	err := scenariobuilder.NewScenario().
+		WithBinary("../todocheck").
+		WithBasepath("./scenarios/ignored_dirs").
+		WithConfig("./test_configs/ignored_dirs.yaml").
+		WithPaths("scenarios/ignored_dirs/ignored_dir1/ignored.go", "scenarios/ignored_dirs/ignored_dir2", "scenarios/ignored_dirs/main.go").
+		ExpectTodoErr(
+			scenariobuilder.NewTodoErr().
+				WithType(errors.TODOErrTypeMalformed).
+				WithLocation("scenarios/ignored_dirs/main.go", 3).
+				ExpectLine("// This is a malformed TODO")).
+		Run()
+	if err != nil {
+		t.Errorf("%s", err)
+	}
+}
+
+func TestIgnoredStdin(t *testing.T) {
+	err := scenariobuilder.NewScenario().
+		WithBinary("../todocheck").
+		WithBasepath("./scenarios/ignored_dirs").
+		WithConfig("./test_configs/ignored_dirs.yaml").
+		WithStdin("scenarios/ignored_dirs/ignored_dir1/unsaved.go", "// TODO This is a malformed todo in an ignored directory\n").
+		Run()
+	if err != nil {
+		t.Errorf("%s", err)
+	}
+}
+
 func TestIgnoredDirectoriesWithDotDot(t *testing.T) {
 	err := scenariobuilder.NewScenario().
 		WithBinary("../todocheck").
@@ -625,6 +1278,82 @@ func TestHashTagTodosWithGithub(t *testing.T) {
 	}
 }
 
+func TestOfflineMode(t *testing.T) {
+	err := scenariobuilder.NewScenario().
+		WithBinary("../todocheck").
+		WithBasepath("./scenarios/offline_todos").
+		WithConfig("./test_configs/offline_github.yaml").
+		WithOfflineFlag().
+		ExpectTodoErr(
+			scenariobuilder.NewTodoErr().
+				WithType(errors.TODOErrTypeInvalidIssueRef).
+				WithLocation("scenarios/offline_todos/main.go", 7).
+				ExpectLine("// TODO J123: This is a todo with an issue reference, which is not valid for github")).
+		ExpectTodoErr(
+			scenariobuilder.NewTodoErr().
+				WithType(errors.TODOErrTypeMalformed).
+				WithLocation("scenarios/offline_todos/main.go", 9).
+				ExpectLine("// TODO: This is a malformed todo")).
+		ExpectTodoErr(
+			scenariobuilder.NewTodoErr().
+				WithType(errors.TODOErrTypeInvalidIssueRef).
+				WithLocation("scenarios/offline_todos/main.go", 11).
+				ExpectLine("/*").
+				ExpectLine(" * TODO GH-3:").
+				ExpectLine(" * This is another issue reference, which is not valid for github").
+				ExpectLine(" */")).
+		Run()
+	if err != nil {
+		t.Errorf("%s", err)
+	}
+}
+
+func TestIssueURLsInOfflineMode(t *testing.T) {
+	err := scenariobuilder.NewScenario().
+		WithBinary("../todocheck").
+		WithBasepath("./scenarios/issue_urls").
+		WithConfig("./test_configs/offline_github.yaml").
+		WithOfflineFlag().
+		ExpectTodoErr(
+			scenariobuilder.NewTodoErr().
+				WithType(errors.TODOErrTypeIssueURLMismatch).
+				WithLocation("scenarios/issue_urls/main.go", 5).
+				ExpectLine("// TODO https://github.com/preslavmihaylov/other-repo/issues/12: This is a todo, annotated with an issue URL of another repository").
+				WithMessage("issue https://github.com/preslavmihaylov/other-repo/issues/12 doesn't belong to github.com/preslavmihaylov/todocheck")).
+		ExpectTodoErr(
+			scenariobuilder.NewTodoErr().
+				WithType(errors.TODOErrTypeInvalidIssueRef).
+				WithLocation("scenarios/issue_urls/main.go", 7).
+				ExpectLine("// TODO https://github.com/preslavmihaylov/todocheck/wiki: This is a todo, annotated with a URL, which isn't an issue")).
+		Run()
+	if err != nil {
+		t.Errorf("%s", err)
+	}
+}
+
+func TestCrossRepoRefsInOfflineMode(t *testing.T) {
+	err := scenariobuilder.NewScenario().
+		WithBinary("../todocheck").
+		WithBasepath("./scenarios/cross_repo_refs").
+		WithConfig("./test_configs/allowed_repositories.yaml").
+		WithOfflineFlag().
+		ExpectTodoErr(
+			scenariobuilder.NewTodoErr().
+				WithType(errors.TODOErrTypeRepoNotAllowed).
+				WithLocation("scenarios/cross_repo_refs/main.go", 7).
+				ExpectLine("// TODO otheruser/repo#7: This is a todo, annotated with an issue of a repository, which is not allowed").
+				WithMessage("issue otheruser/repo#7 belongs to repository otheruser/repo, which is not in allowed_repositories")).
+		ExpectTodoErr(
+			scenariobuilder.NewTodoErr().
+				WithType(errors.TODOErrTypeInvalidIssueRef).
+				WithLocation("scenarios/cross_repo_refs/main.go", 9).
+				ExpectLine("// TODO other/group/repo#7: This is a todo, annotated with an invalid github issue reference")).
+		Run()
+	if err != nil {
+		t.Errorf("%s", err)
+	}
+}
+
 func TestGroovyTodos(t *testing.T) {
 	err := scenariobuilder.NewScenario().
 		WithBinary("../todocheck").
@@ -1,6 +1,7 @@
 package comments
 
 import (
+	"context"
 	"strings"
 
 	"github.com/preslavmihaylov/todocheck/matchers"
@@ -8,10 +9,16 @@ import (
 	"github.com/preslavmihaylov/todocheck/traverser/lines"
 )
 
-// NewTraverser for comments
-func NewTraverser(ignoredPaths []string, callback state.CommentCallback) *Traverser {
+// NewTraverser for comments in the files, which are not ignored & pass the filter. Ignored paths are relative to the basepath.
+// A nil filter passes all files & a nil reader reads files from disk
+func NewTraverser(
+	basepath string, ignoredPaths []string, filter lines.FileFilter, readFile lines.FileReader, callback state.CommentCallback,
+) *Traverser {
 	return &Traverser{
+		basepath:                basepath,
 		ignoredPaths:            ignoredPaths,
+		filter:                  filter,
+		readFile:                readFile,
 		supportedFileExtensions: matchers.SupportedFileExtensions(),
 		state:                   state.NonComment,
 		callback:                callback,
@@ -20,7 +27,10 @@ func NewTraverser(ignoredPaths []string, callback state.CommentCallback) *Traver
 
 // Traverser for comments in a given filename
 type Traverser struct {
+	basepath                string
 	ignoredPaths            []string
+	filter                  lines.FileFilter
+	readFile                lines.FileReader
 	supportedFileExtensions []string
 
 	matcher  matchers.CommentMatcher
@@ -31,10 +41,24 @@ type Traverser struct {
 	state       state.CommentState
 }
 
-// TraversePath and perform a callback on each line in each file
-func (t *Traverser) TraversePath(path string) error {
+// TraversePath and perform a callback on each comment in each file in the given paths
+func (t *Traverser) TraversePath(ctx context.Context, paths ...string) error {
+	return lines.TraversePath(ctx, paths, t.basepath, t.ignoredPaths, t.supportedFileExtensions, t.filter, t.readFile, t.lineCallback())
+}
+
+// TraverseContent and perform a callback on each comment in the given content of the given file
+func (t *Traverser) TraverseContent(filename string, content []byte) error {
+	return lines.TraverseContent(filename, content, t.basepath, t.ignoredPaths, t.supportedFileExtensions, t.lineCallback())
+}
+
+// lineCallback for a new traversal, which starts from a non-comment state
+func (t *Traverser) lineCallback() func(filename, line string, linecnt int) error {
+	t.filename = ""
+	t.state = state.NonComment
+	t.callbackErr = nil
+
 	var prev, curr, next rune
-	return lines.TraversePath(path, t.ignoredPaths, t.supportedFileExtensions, func(filename, line string, linecnt int) error {
+	return func(filename, line string, linecnt int) error {
 		if !strings.HasSuffix(line, "\n") {
 			line += "\n"
 		}
@@ -52,7 +76,7 @@ func (t *Traverser) TraversePath(path string) error {
 		prev = curr
 
 		return t.callbackErr
-	})
+	}
 }
 
 func (t *Traverser) handleStateChange(filename, line string, linecnt int, prevToken, currToken, nextToken rune) error {
@@ -3,11 +3,13 @@ package lines
 import (
 	"bufio"
 	"bytes"
+	"context"
 	"fmt"
 	"io"
 	"log"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/preslavmihaylov/todocheck/logger"
 
@@ -16,25 +18,30 @@ import (
 
 type lineCallback func(filename, line string, linecnt int) error
 
-// TraversePath and perform a callback on each line in each file
-func TraversePath(path string, ignoredPaths, supportedFileExtensions []string, callback lineCallback) error {
-	return filepath.Walk(path, func(file string, info os.FileInfo, err error) error {
-		if err != nil {
-			return fmt.Errorf("couldn't traverse %s: %w", file, err)
-		}
-
-		if isIgnored(ignoredPaths, file) {
-			logger.Info("Skipping ignored file", file)
-			if info.IsDir() {
-				return filepath.SkipDir
-			}
+// FileFilter returns true if the given file should be traversed
+type FileFilter func(filename string) bool
+
+// FileReader returns the content of the given file
+type FileReader func(filename string) ([]byte, error)
+
+// TraversePath and perform a callback on each line in each file in the given paths, which is not ignored & passes the filter.
+// Ignored paths are relative to the basepath & files in more than one of the paths are traversed once.
+// A nil filter passes all files & a nil reader reads files from disk. Traversal stops before the next file once the context is done
+func TraversePath(
+	ctx context.Context, paths []string, basepath string, ignoredPaths, supportedFileExtensions []string,
+	filter FileFilter, readFile FileReader, callback lineCallback,
+) error {
+	if readFile == nil {
+		readFile = os.ReadFile
+	}
 
-			return nil
-		} else if info.IsDir() || !isSupported(supportedFileExtensions, file) {
+	return WalkFiles(ctx, paths, basepath, ignoredPaths, supportedFileExtensions, func(file string, info os.FileInfo) error {
+		if filter != nil && !filter(file) {
+			logger.Info("Skipping filtered file", file)
 			return nil
 		}
 
-		err = traverseFile(file, callback)
+		err := traverseFile(file, readFile, callback)
 		if err != nil {
 			return fmt.Errorf("failed traversing file %s: %w", file, err)
 		}
@@ -43,8 +50,68 @@ func TraversePath(path string, ignoredPaths, supportedFileExtensions []string, c
 	})
 }
 
-func traverseFile(filename string, callback lineCallback) error {
-	buf, err := os.ReadFile(filename)
+// WalkFiles and perform a callback on each supported file in the given paths, which is not ignored. Ignored paths are relative to the basepath.
+// Files in more than one of the paths are visited once. Walking stops before the next file once the context is done
+func WalkFiles(
+	ctx context.Context, paths []string, basepath string, ignoredPaths, supportedFileExtensions []string,
+	callback func(file string, info os.FileInfo) error,
+) error {
+	visited := map[string]bool{}
+	for _, path := range paths {
+		if isIgnoredPath(basepath, ignoredPaths, path) {
+			logger.Info("Skipping ignored path", path)
+			continue
+		}
+
+		err := filepath.Walk(path, func(file string, info os.FileInfo, err error) error {
+			if err != nil {
+				return fmt.Errorf("couldn't traverse %s: %w", file, err)
+			} else if ctxErr := ctx.Err(); ctxErr != nil {
+				return ctxErr
+			}
+
+			if isIgnored(ignoredPaths, file) {
+				logger.Info("Skipping ignored file", file)
+				if info.IsDir() {
+					return filepath.SkipDir
+				}
+
+				return nil
+			} else if info.IsDir() || !isSupported(supportedFileExtensions, file) {
+				return nil
+			} else if key := absPath(file); visited[key] {
+				return nil
+			} else {
+				visited[key] = true
+			}
+
+			return callback(file, info)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// TraverseContent and perform a callback on each line in the given content of the given file, e.g. an unsaved editor buffer.
+// The file doesn't have to exist, but it's skipped if it or any of its parent directories within the basepath is ignored or if it's not supported
+func TraverseContent(
+	filename string, content []byte, basepath string, ignoredPaths, supportedFileExtensions []string, callback lineCallback,
+) error {
+	if isIgnoredPath(basepath, ignoredPaths, filename) {
+		logger.Info("Skipping ignored file", filename)
+		return nil
+	} else if !isSupported(supportedFileExtensions, filename) {
+		return nil
+	}
+
+	return traverseFile(filename, func(string) ([]byte, error) { return content, nil }, callback)
+}
+
+func traverseFile(filename string, readFile FileReader, callback lineCallback) error {
+	buf, err := readFile(filename)
 	if err != nil {
 		return fmt.Errorf("failed to open file %s: %w", filename, err)
 	}
@@ -72,6 +139,35 @@ func traverseFile(filename string, callback lineCallback) error {
 	return nil
 }
 
+func absPath(filename string) string {
+	if abs, err := filepath.Abs(filename); err == nil {
+		return abs
+	}
+
+	return filepath.Clean(filename)
+}
+
+// isIgnoredPath checks if the given path or any of its parent directories within the basepath is ignored.
+// Walked files are skipped along with their ignored parent directories, but paths given directly, e.g. file arguments, have to be checked this way
+func isIgnoredPath(basepath string, ignoredPaths []string, path string) bool {
+	if isIgnored(ignoredPaths, path) {
+		return true
+	}
+
+	rel, err := filepath.Rel(absPath(basepath), absPath(path))
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return false
+	}
+
+	for dir := filepath.ToSlash(rel); dir != "." && dir != "/"; dir = filepath.ToSlash(filepath.Dir(dir)) {
+		if isIgnored(ignoredPaths, dir) {
+			return true
+		}
+	}
+
+	return false
+}
+
 func isIgnored(ignoredPaths []string, path string) bool {
 	if isHidden(path) {
 		return true
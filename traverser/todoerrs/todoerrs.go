@@ -1,54 +1,452 @@
 package todoerrs
 
 import (
+	"context"
+	stderrors "errors"
 	"fmt"
+	"math"
+	"os"
+	"regexp"
+	"strings"
+	"time"
 
 	"github.com/preslavmihaylov/todocheck/checker"
 	"github.com/preslavmihaylov/todocheck/checker/errors"
+	"github.com/preslavmihaylov/todocheck/common"
+	"github.com/preslavmihaylov/todocheck/config"
+	"github.com/preslavmihaylov/todocheck/diff"
 	"github.com/preslavmihaylov/todocheck/fetcher"
+	"github.com/preslavmihaylov/todocheck/issuetracker/taskstatus"
+	"github.com/preslavmihaylov/todocheck/logger"
 	"github.com/preslavmihaylov/todocheck/matchers"
 	"github.com/preslavmihaylov/todocheck/matchers/caseinsensitive"
-	"github.com/preslavmihaylov/todocheck/matchers/state"
+	"github.com/preslavmihaylov/todocheck/matchers/suppression"
 	"github.com/preslavmihaylov/todocheck/traverser/comments"
 )
 
+// errOffline is returned when looking up issue statuses with an offline traverser
+var errOffline = stderrors.New("issue statuses are not looked up in offline mode")
+
 // TodoErrCallback is a function which acts on an encountered todo error
 type TodoErrCallback func(todoerr *errors.TODO) error
 
-// NewTraverser for todo errors
-func NewTraverser(f *fetcher.Fetcher, ignoredPaths, customTodos []string, matchCaseInsensitive bool, callback TodoErrCallback) *Traverser {
-	return &Traverser{
-		comments.NewTraverser(ignoredPaths, commentsCallback(checker.New(f), customTodos, matchCaseInsensitive, callback)),
+// NewTraverser for todo errors. Todos, whose expiry date is before now, are reported as expired
+func NewTraverser(f *fetcher.Pool, cfg *config.Local, now time.Time, callback TodoErrCallback) *Traverser {
+	return newTraverser(f, checker.New(f, cfg, now), cfg, now, callback)
+}
+
+// NewOfflineTraverser for todo errors, which doesn't contact the issue tracker.
+// It only reports malformed & expired todos and issue references, which are not valid for the configured issue tracker
+func NewOfflineTraverser(cfg *config.Local, now time.Time, callback TodoErrCallback) *Traverser {
+	return newTraverser(nil, checker.NewOffline(cfg, now), cfg, now, callback)
+}
+
+func newTraverser(f *fetcher.Pool, c *checker.Checker, cfg *config.Local, now time.Time, callback TodoErrCallback) *Traverser {
+	t := &Traverser{
+		fetcher:                  f,
+		checker:                  c,
+		customTodos:              cfg.CustomTodos,
+		matchCaseInsensitive:     cfg.MatchCaseInsensitive,
+		overrides:                cfg.Overrides,
+		reportUnusedSuppressions: cfg.ReportUnusedSuppressions,
+		now:                      now,
+		callback:                 callback,
 	}
+
+	t.commentsTraverser = comments.NewTraverser(cfg.Basepath, cfg.IgnoredPaths, t.isTraversedFile, t.readFile, t.collectTodo)
+	return t
+}
+
+// LimitToChanges limits the reported todo errors to the todos on the given changed lines.
+// Unchanged files are not traversed at all
+func (t *Traverser) LimitToChanges(changes *diff.Changes) {
+	t.changes = changes
+}
+
+// ReadStaged limits the traversal to the files, staged in the given index & reads their staged content,
+// rather than the one in the working tree
+func (t *Traverser) ReadStaged(index *diff.Index) {
+	t.index = index
+}
+
+// Summary of the todos checked by a traverser
+type Summary struct {
+	// Todos is the amount of checked todos
+	Todos int
+
+	// TodosPerFile is the amount of checked todos in each file
+	TodosPerFile map[string]int
+
+	// FailedLookups is the amount of distinct issues, whose status couldn't be fetched
+	FailedLookups int
 }
 
 // Traverser for todo errors
 type Traverser struct {
-	commentsTraverser *comments.Traverser
+	commentsTraverser    *comments.Traverser
+	fetcher              *fetcher.Pool
+	checker              *checker.Checker
+	customTodos          []string
+	matchCaseInsensitive bool
+	overrides            config.Overrides
+	callback             TodoErrCallback
+
+	// reportUnusedSuppressions reports the suppression directives, which don't suppress any todo errors.
+	// Otherwise, suppressed todos are not checked at all
+	reportUnusedSuppressions bool
+	now                      time.Time
+
+	// changes are the lines, the reported todo errors are limited to. If nil, all todo errors are reported
+	changes *diff.Changes
+
+	// index contains the staged files, which are traversed instead of the working tree. If nil, files are read from disk
+	index *diff.Index
+
+	todos          []*todoComment
+	suppressions   []*suppressedLines
+	checked        int
+	checkedPerFile map[string]int
+
+	// disabled is the last todocheck:disable directive in the current file, which is not followed by a todocheck:enable
+	disabled *suppressedLines
 }
 
-func commentsCallback(chk *checker.Checker, customTodos []string, matchCaseInsensitive bool, todoErrCallback TodoErrCallback) state.CommentCallback {
-	return func(comment, filepath string, lines []string, linecnt int) error {
-		matcher := matchers.TodoMatcherForFile(filepath, customTodos)
-		if matchCaseInsensitive {
-			matcher = caseinsensitive.NewTodoMatcher(matcher)
+// todoComment is a comment, matched as a todo during traversal, which is pending a check
+type todoComment struct {
+	matcher  matchers.TodoMatcher
+	comment  string
+	filepath string
+	lines    []string
+	linecnt  int
+
+	// source of the comment, including its suppression directives
+	source string
+}
+
+// suppressedLines are the lines of a file, whose todo errors are suppressed by a directive
+type suppressedLines struct {
+	directive *suppression.Directive
+	comment   string
+	filepath  string
+	lines     []string
+	linecnt   int
+
+	// from & to are the first & the last suppressed lines
+	from, to int
+
+	// isUsed is true if the directive suppressed any todo errors
+	isUsed bool
+}
+
+// TraversePath for todo errors in the files in the given paths. Callback is invoked on encountered error.
+// All todos are collected first, so that the statuses of their issues are fetched concurrently.
+// Afterwards, the todos are checked in the order they were encountered.
+//
+// If the context is done midway, the callback is still invoked for all errors which can be determined
+// without contacting the issue tracker & the context's error is returned afterwards
+func (t *Traverser) TraversePath(ctx context.Context, paths ...string) error {
+	return t.check(ctx, func() error {
+		return t.commentsTraverser.TraversePath(ctx, paths...)
+	})
+}
+
+// TraverseContent for todo errors in the given content of the given file, e.g. an unsaved editor buffer.
+// The file's todo matcher is chosen by its name
+func (t *Traverser) TraverseContent(ctx context.Context, filename string, content []byte) error {
+	return t.check(ctx, func() error {
+		return t.commentsTraverser.TraverseContent(filename, content)
+	})
+}
+
+// check the todos, collected by the given traversal
+func (t *Traverser) check(ctx context.Context, traverse func() error) error {
+	t.todos = nil
+	t.suppressions = nil
+	t.disabled = nil
+	t.checked = 0
+	t.checkedPerFile = map[string]int{}
+	if err := traverse(); err != nil && ctx.Err() == nil {
+		return err
+	}
+
+	if t.fetcher != nil {
+		t.fetcher.Prefetch(ctx, t.issueRefs())
+	}
+
+	unchecked := 0
+	for _, todo := range t.todos {
+		suppressed := t.suppressionFor(todo)
+		if suppressed != nil && !t.reportUnusedSuppressions {
+			continue
 		}
 
-		todoErr, err := chk.Check(matcher, comment, filepath, lines, linecnt)
-		if err != nil {
+		todoErrs, err := t.checker.Check(ctx, todo.matcher, todo.comment, todo.filepath, todo.lines, todo.linecnt)
+		if err != nil && ctx.Err() != nil {
+			unchecked++
+			continue
+		} else if err != nil {
 			return fmt.Errorf("couldn't check todo line: %w", err)
-		} else if todoErr != nil {
-			err = todoErrCallback(todoErr)
+		}
+
+		t.checked++
+		t.checkedPerFile[todo.filepath]++
+		if suppressed != nil && len(todoErrs) > 0 {
+			suppressed.isUsed = true
+			continue
+		}
+
+		for _, todoErr := range todoErrs {
+			err = t.callback(todoErr.WithComment(todo.comment))
 			if err != nil {
 				return fmt.Errorf("received error from todo err callback: %w", err)
 			}
 		}
+	}
+
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("traversal was stopped early & %d of the collected todos were left unchecked: %w", unchecked, err)
+	}
+
+	if t.reportUnusedSuppressions {
+		return t.reportUnused()
+	}
+
+	return nil
+}
+
+// reportUnused suppression directives, which didn't suppress any todo errors
+func (t *Traverser) reportUnused() error {
+	for _, s := range t.suppressions {
+		if s.isUsed || !t.isChanged(s.filepath, s.lines, s.linecnt) {
+			continue
+		}
+
+		var expiry *time.Time
+		if s.directive.IsExpired(t.now) {
+			expiry = s.directive.Until
+		}
 
+		todoErr := errors.UnusedSuppressionErr(s.filepath, s.lines, s.linecnt, s.directive.String(), expiry)
+		err := t.callback(todoErr.WithComment(s.comment))
+		if err != nil {
+			return fmt.Errorf("received error from todo err callback: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// suppressionFor the given todo or nil if its errors are not suppressed. Expired directives don't suppress todo errors
+func (t *Traverser) suppressionFor(todo *todoComment) *suppressedLines {
+	for _, s := range t.suppressions {
+		if s.filepath == todo.filepath && s.from <= todo.linecnt && todo.linecnt <= s.to && !s.directive.IsExpired(t.now) {
+			return s
+		}
+	}
+
+	return nil
+}
+
+// RefreshStatuses of the issues on the next traversal, instead of reusing the ones fetched by previous traversals
+func (t *Traverser) RefreshStatuses() {
+	if t.fetcher != nil {
+		t.fetcher.Reset()
+	}
+
+	t.checker.ResetFailedLookups()
+}
+
+// Summary of the todos checked so far
+func (t *Traverser) Summary() Summary {
+	return Summary{
+		Todos:         t.checked,
+		TodosPerFile:  t.checkedPerFile,
+		FailedLookups: t.checker.FailedLookups(),
+	}
+}
+
+// CustomTodosFor the given file & whether they are matched case-insensitively, as set by the configuration & its override for the file
+func (t *Traverser) CustomTodosFor(filepath string) (customTodos []string, matchCaseInsensitive bool) {
+	customTodos, matchCaseInsensitive = t.customTodos, t.matchCaseInsensitive
+	if o := t.overrides.For(filepath); o != nil {
+		if o.CustomTodos != nil {
+			customTodos = o.CustomTodos
+		}
+
+		if o.MatchCaseInsensitive != nil {
+			matchCaseInsensitive = *o.MatchCaseInsensitive
+		}
+	}
+
+	return customTodos, matchCaseInsensitive
+}
+
+// Todo, found by a traversal
+type Todo struct {
+	Filename string
+
+	// Line of the todo's first line of source code
+	Line  int
+	Lines []string
+
+	// Comment is the todo's source code, which is part of its lines, including the comment delimiters.
+	// The zero runes, which comment matchers are fed at the end of each line, are removed from multi-line comments
+	Comment string
+
+	// Keyword of the todo as written, one of the custom todos of its file
+	Keyword string
+
+	// TaskIDs of the issues, the todo references, as returned by checker.TaskIDFor. Malformed todos don't reference any issues
+	TaskIDs     []string
+	IsMalformed bool
+}
+
+// Todos found by the last traversal, in the order they were encountered
+func (t *Traverser) Todos() []*Todo {
+	var todos []*Todo
+	keywordPatterns := map[string]*regexp.Regexp{}
+	for _, todo := range t.todos {
+		result := &Todo{
+			Filename:    todo.filepath,
+			Line:        todo.linecnt,
+			Lines:       todo.lines,
+			Comment:     strings.ReplaceAll(todo.source, "\x00", ""),
+			IsMalformed: todo.matcher == nil || !todo.matcher.IsValid(todo.comment),
+		}
+
+		customTodos, matchCaseInsensitive := t.CustomTodosFor(todo.filepath)
+		pattern := common.ArrayAsRegexAnyMatchExpression(customTodos)
+		if matchCaseInsensitive {
+			pattern = "(?i)" + pattern
+		}
+
+		if keywordPatterns[pattern] == nil {
+			keywordPatterns[pattern] = regexp.MustCompile(pattern)
+		}
+
+		result.Keyword = keywordPatterns[pattern].FindString(todo.comment)
+		if !result.IsMalformed {
+			refs, _ := t.checker.ExtractIssueRefs(todo.matcher, todo.comment)
+			for _, ref := range refs {
+				if taskID, err := t.checker.TaskIDFor(ref, todo.filepath); err == nil {
+					result.TaskIDs = append(result.TaskIDs, taskID)
+				}
+			}
+		}
+
+		todos = append(todos, result)
+	}
+
+	return todos
+}
+
+// IssueStatus of the given task, as returned by checker.TaskIDFor. Statuses, fetched by previous traversals, are reused
+func (t *Traverser) IssueStatus(ctx context.Context, taskID string) (taskstatus.Result, error) {
+	if t.fetcher == nil {
+		return taskstatus.Result{}, errOffline
+	}
+
+	return t.fetcher.Fetch(ctx, taskID)
+}
+
+// WebURLFor the page of the given task, as returned by checker.TaskIDFor, in its issue tracker's web UI
+func (t *Traverser) WebURLFor(taskID string) string {
+	return t.checker.WebURLFor(taskID)
+}
+
+func (t *Traverser) collectTodo(comment, filepath string, lines []string, linecnt int) error {
+	t.collectSuppressions(comment, filepath, lines, linecnt)
+	source, comment := comment, suppression.Strip(comment)
+
+	customTodos, matchCaseInsensitive := t.CustomTodosFor(filepath)
+	matcher := matchers.TodoMatcherForFile(filepath, customTodos)
+	if matchCaseInsensitive {
+		matcher = caseinsensitive.NewTodoMatcher(matcher)
+	}
+
+	if matcher != nil && !matcher.IsMatch(comment) {
+		return nil
+	} else if !t.isChanged(filepath, lines, linecnt) {
 		return nil
 	}
+
+	t.todos = append(t.todos, &todoComment{matcher, comment, filepath, lines, linecnt, source})
+	return nil
 }
 
-// TraversePath for todo errors. Callback is invoked on encountered error
-func (t *Traverser) TraversePath(path string) error {
-	return t.commentsTraverser.TraversePath(path)
+// isTraversedFile returns true if the given file is changed & staged, when the traversal is limited to changes or staged files
+func (t *Traverser) isTraversedFile(filepath string) bool {
+	return (t.changes == nil || t.changes.HasFile(filepath)) && (t.index == nil || t.index.HasFile(filepath))
+}
+
+func (t *Traverser) readFile(filepath string) ([]byte, error) {
+	if t.index != nil {
+		return t.index.ReadFile(filepath)
+	}
+
+	return os.ReadFile(filepath)
+}
+
+// isChanged returns true if any of the given lines was changed or if the todo errors are not limited to changed lines
+func (t *Traverser) isChanged(filepath string, lines []string, linecnt int) bool {
+	return t.changes == nil || t.changes.HasLines(filepath, linecnt, linecnt+len(lines)-1)
+}
+
+// collectSuppressions from the directives in the given comment.
+// Directives with an invalid expiry date are skipped, so that the todo errors they were meant to suppress are still reported
+func (t *Traverser) collectSuppressions(comment, filepath string, lines []string, linecnt int) {
+	if t.disabled != nil && t.disabled.filepath != filepath {
+		t.disabled = nil
+	}
+
+	directives, err := suppression.Parse(comment)
+	if err != nil {
+		logger.Infof("Skipping invalid suppression directive in %s:%d: %s\n", filepath, linecnt, err)
+	}
+
+	for _, d := range directives {
+		s := &suppressedLines{directive: d, comment: comment, filepath: filepath, lines: lines, linecnt: linecnt}
+		switch d.Kind {
+		case suppression.IgnoreNextLine:
+			s.from, s.to = linecnt+len(lines), linecnt+len(lines)
+		case suppression.Ignore:
+			s.from, s.to = linecnt, linecnt+len(lines)-1
+		case suppression.Disable:
+			s.from, s.to = linecnt, math.MaxInt
+			t.disabled = s
+		case suppression.Enable:
+			if t.disabled != nil {
+				t.disabled.to = linecnt
+				t.disabled = nil
+			}
+
+			continue
+		}
+
+		t.suppressions = append(t.suppressions, s)
+	}
+}
+
+func (t *Traverser) issueRefs() []string {
+	var refs []string
+	for _, todo := range t.todos {
+		if todo.matcher == nil || !todo.matcher.IsValid(todo.comment) {
+			continue
+		} else if !t.reportUnusedSuppressions && t.suppressionFor(todo) != nil {
+			continue
+		}
+
+		todoRefs, err := t.checker.ExtractIssueRefs(todo.matcher, todo.comment)
+		if err != nil {
+			continue
+		}
+
+		for _, ref := range todoRefs {
+			if taskID, err := t.checker.TaskIDFor(ref, todo.filepath); err == nil {
+				refs = append(refs, taskID)
+			}
+		}
+	}
+
+	return refs
 }
@@ -4,6 +4,8 @@ import (
 	"errors"
 	"fmt"
 	"net/url"
+	"regexp"
+	"strings"
 
 	"github.com/fatih/color"
 	"github.com/preslavmihaylov/todocheck/config"
@@ -37,6 +39,38 @@ func Validate(cfg *config.Local, tracker issuetracker.IssueTracker) []error {
 		errs = append(errs, err)
 	}
 
+	if err := validateConcurrency(cfg); err != nil {
+		errs = append(errs, err)
+	}
+
+	if err := validateRetries(cfg); err != nil {
+		errs = append(errs, err)
+	}
+
+	if !cfg.OnTrackerError.IsValid() {
+		errs = append(errs, fmt.Errorf("invalid on_tracker_error: %q. Valid options are %v", cfg.OnTrackerError, config.ValidOnTrackerErrors))
+	}
+
+	if !cfg.OwnerPolicy.IsValid() {
+		errs = append(errs, fmt.Errorf("invalid owner_policy: %q. Valid options are %v", cfg.OwnerPolicy, config.ValidOwnerPolicies))
+	}
+
+	if !cfg.RequiredOpenIssues.IsValid() {
+		errs = append(errs, fmt.Errorf("invalid required_open_issues: %q. Valid options are %v", cfg.RequiredOpenIssues, config.ValidRequiredOpenIssues))
+	}
+
+	if !cfg.AllowedRepositories.IsValid() {
+		errs = append(errs, fmt.Errorf("invalid allowed_repositories: %v. Entries must be repository paths, optionally containing wildcards", cfg.AllowedRepositories))
+	}
+
+	errs = append(errs, validateStatusMapping(cfg.StatusMapping, "")...)
+
+	errs = append(errs, validateNamedIssueTrackers(cfg, false)...)
+
+	if cfg.RequestTimeout < 0 {
+		errs = append(errs, fmt.Errorf("invalid request_timeout: %s. It must not be negative", cfg.RequestTimeout))
+	}
+
 	if cfg.Auth.Token == "" && cfg.IssueTracker == config.IssueTrackerGithub {
 		fmt.Fprintln(color.Output, color.YellowString(
 			"WARNING: Github has API rate limits for all requests which do not contain a token.\n"+
@@ -53,6 +87,79 @@ func Validate(cfg *config.Local, tracker issuetracker.IssueTracker) []error {
 	return errs
 }
 
+// ValidateOffline validates the values of given configuration, which are relevant when the issue tracker is not contacted
+func ValidateOffline(cfg *config.Local) []error {
+	var errs []error
+	if cfg.IssueTracker != config.IssueTrackerInvalid {
+		if err := validateIssueTracker(cfg); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return append(errs, validateNamedIssueTrackers(cfg, true)...)
+}
+
+// validateNamedIssueTrackers of the given configuration, including the ones set by overrides.
+// Their auth configuration is only validated if they are contacted
+func validateNamedIssueTrackers(cfg *config.Local, isOffline bool) []error {
+	var errs []error
+	names := map[string]bool{}
+	for _, t := range cfg.IssueTrackers {
+		if t.IsOverride() {
+			errs = append(errs, validateNamedIssueTracker(t, isOffline)...)
+			continue
+		}
+
+		if !t.IsValidName() {
+			errs = append(errs, fmt.Errorf("invalid issue tracker name: %q. It must start with a letter & contain only letters, digits, dashes & underscores", t.Name))
+		} else if names[strings.ToLower(t.Name)] {
+			errs = append(errs, fmt.Errorf("duplicate issue tracker name: %q", t.Name))
+		}
+
+		names[strings.ToLower(t.Name)] = true
+		errs = append(errs, validateNamedIssueTracker(t, isOffline)...)
+	}
+
+	return errs
+}
+
+func validateNamedIssueTracker(t *config.NamedIssueTracker, isOffline bool) []error {
+	var errs []error
+	name := t.DisplayName()
+	if !t.IssueTracker.IsValid() {
+		errs = append(errs, fmt.Errorf("invalid issue tracker for %s: %q is not supported", name, t.IssueTracker))
+	} else if !t.IssueTracker.IsValidOrigin(t.Origin) {
+		errs = append(errs, fmt.Errorf("%s is not a valid origin for issue tracker %s (%s)", t.Origin, name, t.IssueTracker))
+	}
+
+	if _, err := regexp.Compile(t.IDPattern); err != nil {
+		errs = append(errs, fmt.Errorf("invalid id_pattern for issue tracker %s: %w", name, err))
+	}
+
+	errs = append(errs, validateStatusMapping(t.StatusMapping, " of issue tracker "+name)...)
+	if t.Concurrency < 1 {
+		errs = append(errs, fmt.Errorf("invalid concurrency for issue tracker %s: %d. It must be a positive number", name, t.Concurrency))
+	}
+
+	if isOffline || !t.IssueTracker.IsValid() {
+		return errs
+	}
+
+	if !t.IssueTracker.IsValidAuthType(t.Auth.Type) {
+		errs = append(errs, fmt.Errorf("unsupported authentication type for %s (%s): %s", name, t.IssueTracker, t.Auth.Type.String()))
+	} else if t.Auth.Type == config.AuthTypeOffline && t.Auth.OfflineURL == "" {
+		errs = append(errs, fmt.Errorf("auth type chosen for %s was %q but \"offline_url\" is not set", name, t.Auth.Type))
+	}
+
+	if t.IssueTracker == config.IssueTrackerJira && t.Auth.Type == config.AuthTypeAPIToken {
+		if _, ok := t.Auth.Options["username"]; !ok {
+			errs = append(errs, fmt.Errorf("api token authentication for JIRA requires username to be set for %s - https://github.com/preslavmihaylov/todocheck#jira", name))
+		}
+	}
+
+	return errs
+}
+
 func validateIssueTracker(cfg *config.Local) error {
 	if !cfg.IssueTracker.IsValid() {
 		return fmt.Errorf("invalid issue tracker: %q is not supported", cfg.IssueTracker)
@@ -104,3 +211,34 @@ func validateIssueTrackerAuthType(cfg *config.Local) error {
 	}
 	return nil
 }
+
+// validateStatusMapping's mapped statuses. The suffix of the errors names the issue tracker, the mapping belongs to, if it's not the top-level one
+func validateStatusMapping(mapping config.StatusMapping, suffix string) []error {
+	var errs []error
+	for rawStatus, status := range mapping {
+		if !status.IsValid() {
+			errs = append(errs, fmt.Errorf("invalid status_mapping%s for %q: %q. Valid options are %v", suffix, rawStatus, status, config.ValidMappedStatuses))
+		}
+	}
+
+	return errs
+}
+
+func validateConcurrency(cfg *config.Local) error {
+	if cfg.Concurrency < 1 {
+		return fmt.Errorf("invalid concurrency: %d. It must be a positive number", cfg.Concurrency)
+	}
+
+	return nil
+}
+
+func validateRetries(cfg *config.Local) error {
+	r := cfg.Retries
+	if r.MaxRetries < 0 || r.MinBackoff < 0 || r.MaxBackoff < 0 || r.MaxWait < 0 {
+		return errors.New("invalid retries configuration. max_retries, min_backoff, max_backoff & max_wait must not be negative")
+	} else if r.MinBackoff > r.MaxBackoff {
+		return fmt.Errorf("invalid retries configuration. min_backoff (%s) must not be greater than max_backoff (%s)", r.MinBackoff, r.MaxBackoff)
+	}
+
+	return nil
+}
@@ -0,0 +1,154 @@
+package main
+
+import (
+	"context"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/preslavmihaylov/todocheck/baseline"
+	todocheckerrors "github.com/preslavmihaylov/todocheck/checker/errors"
+	"github.com/preslavmihaylov/todocheck/logger"
+	"github.com/preslavmihaylov/todocheck/traverser/todoerrs"
+	"github.com/preslavmihaylov/todocheck/watcher"
+)
+
+// watchSession keeps the todo errors of each watched file between iterations, so that only changed files are checked again
+type watchSession struct {
+	baseline *baseline.Baseline
+	format   string
+
+	// errs & the amount of checked todos of each file, keyed by its path
+	errs  map[string][]*todocheckerrors.TODO
+	todos map[string]int
+
+	// collected are the todo errors, found by the current iteration
+	collected []*todocheckerrors.TODO
+}
+
+func newWatchSession(todoBaseline *baseline.Baseline, format string) *watchSession {
+	return &watchSession{
+		baseline: todoBaseline,
+		format:   format,
+		errs:     map[string][]*todocheckerrors.TODO{},
+		todos:    map[string]int{},
+	}
+}
+
+// collect is the todo error callback of the session's traversals
+func (s *watchSession) collect(todoErr *todocheckerrors.TODO) error {
+	s.collected = append(s.collected, todoErr)
+	return nil
+}
+
+// run the session until the context is done. The watched files are polled every pollInterval & the changed ones are checked again.
+// Every refreshInterval, all files are checked again with freshly fetched issue statuses
+func (s *watchSession) run(
+	ctx context.Context, traverser *todoerrs.Traverser, w *watcher.Watcher, pollInterval, refreshInterval time.Duration,
+) {
+	poll := time.NewTicker(pollInterval)
+	defer poll.Stop()
+
+	var refresh <-chan time.Time
+	if refreshInterval > 0 {
+		refreshTicker := time.NewTicker(refreshInterval)
+		defer refreshTicker.Stop()
+		refresh = refreshTicker.C
+	}
+
+	isRefresh := false
+	for {
+		changed, removed, err := w.Poll(ctx)
+		if err != nil && ctx.Err() == nil {
+			log.Printf("couldn't poll watched files: %s\n", err)
+		} else if err == nil {
+			if isRefresh {
+				changed = w.Files()
+				isRefresh = false
+			}
+
+			if len(changed) > 0 || len(removed) > 0 {
+				w.Forget(s.check(ctx, traverser, changed, removed)...)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-poll.C:
+		case <-refresh:
+			logger.Info("Refreshing issue statuses")
+			traverser.RefreshStatuses()
+			isRefresh = true
+		}
+	}
+}
+
+// check the changed files again, print the updated todo errors of all watched files & return the files, which couldn't be checked.
+// If the check fails, the previous errors of the changed files are kept & nothing is printed
+func (s *watchSession) check(ctx context.Context, traverser *todoerrs.Traverser, changed, removed []string) (failed []string) {
+	for _, file := range removed {
+		delete(s.errs, file)
+		delete(s.todos, file)
+	}
+
+	s.collected = nil
+	err := traverser.TraversePath(ctx, changed...)
+	if ctx.Err() != nil {
+		return changed
+	} else if err != nil {
+		log.Printf("couldn't check changed files: %s\n", err)
+		return changed
+	}
+
+	summary := traverser.Summary()
+
+	for _, file := range changed {
+		delete(s.errs, file)
+		s.todos[file] = summary.TodosPerFile[file]
+	}
+
+	for _, todoErr := range s.collected {
+		s.errs[todoErr.Filename()] = append(s.errs[todoErr.Filename()], todoErr)
+	}
+
+	s.print(summary.FailedLookups)
+	return nil
+}
+
+// print the todo errors of all watched files, which are not in the baseline, ordered by file
+func (s *watchSession) print(failedLookups int) {
+	var files []string
+	for file := range s.errs {
+		files = append(files, file)
+	}
+
+	sort.Strings(files)
+	if s.baseline != nil {
+		s.baseline.ResetMatches()
+	}
+
+	todoErrs := []*todocheckerrors.TODO{}
+	baselined := 0
+	for _, file := range files {
+		for _, todoErr := range s.errs[file] {
+			if s.baseline != nil && !todoErr.IsWarning() && s.baseline.Match(todoErr) {
+				baselined++
+				continue
+			}
+
+			todoErrs = append(todoErrs, todoErr)
+		}
+	}
+
+	todos := 0
+	for _, count := range s.todos {
+		todos += count
+	}
+
+	if err := printTodoErrs(todoErrs, s.format); err != nil {
+		log.Fatalf("couldn't print todo errors: %s\n", err)
+	}
+
+	printSummary(todoerrs.Summary{Todos: todos, FailedLookups: failedLookups}, todoErrs, baselined, s.format)
+}
@@ -0,0 +1,86 @@
+// Package watcher detects changes to the files todocheck checks by polling their modification times
+package watcher
+
+import (
+	"context"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/preslavmihaylov/todocheck/matchers"
+	"github.com/preslavmihaylov/todocheck/traverser/lines"
+)
+
+// Watcher for the supported files in a set of paths, which are not ignored
+type Watcher struct {
+	paths                   []string
+	basepath                string
+	ignoredPaths            []string
+	supportedFileExtensions []string
+
+	// files seen on the last poll, keyed by their path
+	files map[string]fileState
+}
+
+// fileState is compared between polls to detect modified files
+type fileState struct {
+	modTime time.Time
+	size    int64
+}
+
+// New watcher for the files in the given paths, which are not ignored. Ignored paths are relative to the basepath
+func New(paths []string, basepath string, ignoredPaths []string) *Watcher {
+	return &Watcher{
+		paths:                   paths,
+		basepath:                basepath,
+		ignoredPaths:            ignoredPaths,
+		supportedFileExtensions: matchers.SupportedFileExtensions(),
+		files:                   map[string]fileState{},
+	}
+}
+
+// Poll the watched files & return the ones, which were added or modified & the ones, which were removed since the last poll.
+// On the first poll, all files are returned as changed. Both lists are sorted
+func (w *Watcher) Poll(ctx context.Context) (changed, removed []string, err error) {
+	files := map[string]fileState{}
+	err = lines.WalkFiles(ctx, w.paths, w.basepath, w.ignoredPaths, w.supportedFileExtensions, func(file string, info os.FileInfo) error {
+		files[file] = fileState{info.ModTime(), info.Size()}
+		if prev, ok := w.files[file]; !ok || prev != files[file] {
+			changed = append(changed, file)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for file := range w.files {
+		if _, ok := files[file]; !ok {
+			removed = append(removed, file)
+		}
+	}
+
+	w.files = files
+	sort.Strings(changed)
+	sort.Strings(removed)
+	return changed, removed, nil
+}
+
+// Forget the given files, so that the next poll returns them as changed, e.g. as they couldn't be checked
+func (w *Watcher) Forget(files ...string) {
+	for _, file := range files {
+		delete(w.files, file)
+	}
+}
+
+// Files seen on the last poll, sorted
+func (w *Watcher) Files() []string {
+	var files []string
+	for file := range w.files {
+		files = append(files, file)
+	}
+
+	sort.Strings(files)
+	return files
+}
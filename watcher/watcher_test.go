@@ -0,0 +1,70 @@
+package watcher
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestPoll(t *testing.T) {
+	dir := t.TempDir()
+	write := func(filename, content string) string {
+		filename = filepath.Join(dir, filename)
+		if err := os.MkdirAll(filepath.Dir(filename), 0755); err != nil {
+			t.Fatalf("Couldn't create directory: %v", err)
+		}
+
+		if err := os.WriteFile(filename, []byte(content), 0644); err != nil {
+			t.Fatalf("Couldn't write file: %v", err)
+		}
+
+		return filename
+	}
+
+	poll := func(w *Watcher, wantChanged, wantRemoved []string) {
+		t.Helper()
+		changed, removed, err := w.Poll(context.Background())
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if !reflect.DeepEqual(changed, wantChanged) || !reflect.DeepEqual(removed, wantRemoved) {
+			t.Errorf("Got changed %v & removed %v, expected %v & %v", changed, removed, wantChanged, wantRemoved)
+		}
+	}
+
+	main := write("main.go", "package main\n")
+	other := write("other.go", "package main\n")
+	write("notes.txt", "TODO\n")
+	write("vendor/lib.go", "package lib\n")
+
+	w := New([]string{dir}, dir, []string{"**/vendor"})
+	poll(w, []string{main, other}, nil)
+	poll(w, nil, nil)
+
+	write("main.go", "package main\n\n// TODO J1: modified\n")
+	script := write("script.py", "# TODO J2: added\n")
+	if err := os.Remove(other); err != nil {
+		t.Fatalf("Couldn't remove file: %v", err)
+	}
+
+	poll(w, []string{main, script}, []string{other})
+
+	// a file is reported as changed when its modification time changes, even if its size doesn't
+	if err := os.Chtimes(main, time.Time{}, time.Unix(0, 0)); err != nil {
+		t.Fatalf("Couldn't change modification time: %v", err)
+	}
+	poll(w, []string{main}, nil)
+
+	// forgotten files, e.g. ones which couldn't be checked, are reported as changed on the next poll
+	w.Forget(script)
+	poll(w, []string{script}, nil)
+	poll(w, nil, nil)
+
+	if got := w.Files(); !reflect.DeepEqual(got, []string{main, script}) {
+		t.Errorf("Got files %v, expected %v", got, []string{main, script})
+	}
+}